@@ -10,7 +10,8 @@ import "github.com/booldesign/validator"
  */
 
 const (
-	CodeRequestParamsInvalid = "request.params.invalid"
+	CodeRequestParamsInvalid  = "request.params.invalid"
+	CodeValidationRuleInvalid = "validation.rule.invalid"
 )
 
 type Error struct {
@@ -19,14 +20,18 @@ type Error struct {
 	Fields  map[string]string `json:"fields"`  // 错误字段信息
 }
 
-// 参数验证&错误响应
+// 参数验证&错误响应，返回所有未通过验证的字段
 func Validator(params func(string) string, rules []validator.ValidationItem) (map[string]string, *Error) {
-	data, field, err := validator.Validation(params, rules)
+	data, err := validator.ValidationAll(params, rules)
 	if err != nil {
+		errs, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return nil, &Error{Code: CodeValidationRuleInvalid, Message: err.Error()}
+		}
 		return nil, &Error{
 			Code:    CodeRequestParamsInvalid,
-			Message: err.Error(),
-			Fields:  map[string]string{field: err.Error()},
+			Message: errs.Flatten()[0].Errors[0].Error(),
+			Fields:  errs.Fields(),
 		}
 	}
 
@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"fmt"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/25 10:20
+ * @Desc: 年龄规则，根据出生日期或身份证号码中的出生日期计算周岁
+ *
+ * {Rule: "age", Data: 18}               年满 18 周岁
+ * {Rule: "age", Data: []int{18, 60}}    18 - 60 周岁
+ *
+ * 参数值按验证项的日期格式规则解析，无法解析时按身份证号码解析，见 ParseIDCard
+ * 按 Config 的时钟及时区计算到日，2 月 29 日出生的在非闰年 3 月 1 日满周岁
+ */
+
+const (
+	ValidateValAgeBetween = "%s 对应的年龄必须在 %d - %d 周岁之间"
+	ValidateValAgeMin     = "%s 对应的年龄不能小于 %d 周岁"
+	ValidateValAgeInvalid = "%s 不是有效的出生日期或身份证号码"
+)
+
+const (
+	MsgAgeBetween = "age.between"
+	MsgAgeMin     = "age.min"
+	MsgAgeInvalid = "age.invalid"
+)
+
+func init() {
+	ruleRegistry["age"] = &ruleEntry{compile: compileAge}
+}
+
+func compileAge(data interface{}) (RuleFunc, error) {
+	min, max := 0, -1
+	switch d := data.(type) {
+	case int:
+		min = d
+	case []int:
+		if len(d) != 2 {
+			return nil, fmt.Errorf(ConfigDataLength, 2)
+		}
+		if d[0] > d[1] {
+			return nil, fmt.Errorf(ConfigDataRange, d[0], d[1])
+		}
+		min, max = d[0], d[1]
+	default:
+		return nil, fmt.Errorf(ConfigDataTypeNotAllow, data)
+	}
+
+	return func(c *RuleContext) error {
+		if c.Value == "" {
+			return nil
+		}
+		loc := c.Config().Loc()
+		birthday, ok := parseItemTime(c.Item, c.Value, loc)
+		if !ok {
+			if card, err := c.Config().ParseIDCard(c.Value); err == nil {
+				birthday, ok = card.Birthday, true
+			}
+		}
+		if !ok {
+			return newFieldError(c.Item, c.Index, c.Value, MsgAgeInvalid, ValidateValAgeInvalid)
+		}
+
+		age, ok := ageAt(birthday, c.Config().Now())
+		switch {
+		case max < 0 && (!ok || age < min):
+			return newFieldError(c.Item, c.Index, c.Value, MsgAgeMin, ValidateValAgeMin, min)
+		case max >= 0 && (!ok || age < min || age > max):
+			return newFieldError(c.Item, c.Index, c.Value, MsgAgeBetween, ValidateValAgeBetween, min, max)
+		}
+		return nil
+	}, nil
+}
+
+// 出生日期到 now 所在日期的周岁，出生日期晚于 now 时返回 false
+func ageAt(birthday, now time.Time) (int, bool) {
+	by, bm, bd := birthday.In(now.Location()).Date()
+	ny, nm, nd := now.Date()
+	if by > ny || (by == ny && (bm > nm || (bm == nm && bd > nd))) {
+		return 0, false
+	}
+
+	age := ny - by
+	if nm < bm || (nm == bm && nd < bd) {
+		age--
+	}
+	return age, true
+}
@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/25 15:35
+ * @Desc:
+ */
+
+// 固定在东八区某日中午的配置
+func configAt(year int, month time.Month, day int) *Config {
+	loc := time.FixedZone("CST", 8*60*60)
+	now := time.Date(year, month, day, 12, 0, 0, 0, loc)
+	return &Config{Location: loc, Clock: ClockFunc(func() time.Time { return now })}
+}
+
+func TestAge(t *testing.T) {
+	tests := []struct {
+		rule   string
+		val    string
+		config *Config
+		expect bool
+	}{
+		{"age:18", "2003-01-01", configAt(2021, 1, 1), true},
+		{"age:18", "2003-01-02", configAt(2021, 1, 1), false},
+		{"age:0", "2021-01-01", configAt(2021, 1, 1), true},
+		{"age:0", "2021-01-02", configAt(2021, 1, 1), false},
+		{"age:18,60", "1960-06-30", configAt(2021, 6, 30), false},
+		{"age:18,60", "1960-07-01", configAt(2021, 6, 30), true},
+		// 闰日出生，非闰年 3 月 1 日满周岁
+		{"age:18", "2000-02-29", configAt(2018, 2, 28), false},
+		{"age:18", "2000-02-29", configAt(2018, 3, 1), true},
+		{"age:20", "2000-02-29", configAt(2020, 2, 29), true},
+		// 身份证号码
+		{"age:18", "110101200301010012", configAt(2021, 1, 1), true},
+		{"age:18", "110101200301020018", configAt(2021, 1, 1), false},
+		{"age:18,40", "110101850101001", configAt(2021, 1, 1), true},
+		{"age:18", "110101200302300011", configAt(2021, 1, 1), false},
+		{"age:18", "110101200301010011", configAt(2021, 1, 1), false},
+		{"age:18", "abc", configAt(2021, 1, 1), false},
+		{"datetime|age:18", "2003-01-01 23:00:00", configAt(2021, 1, 1), true},
+	}
+
+	for _, test := range tests {
+		rules := []ValidationItem{{Key: "v", Name: "出生日期", Rules: MustParseRules(test.rule)}}
+		_, err := ValidationAll(testParams(map[string]string{"v": test.val}), rules, WithConfig(test.config))
+		if (err == nil) != test.expect {
+			t.Errorf("ValidationAll(%s, %s) = %v", test.rule, test.val, err)
+		}
+	}
+}
+
+func TestAgeConfig(t *testing.T) {
+	for _, data := range []interface{}{"18", []int{18}, []int{60, 18}} {
+		if _, err := Compile([]ValidationItem{{Key: "v", Rules: []ValidationRule{{Rule: "age", Data: data}}}}); err == nil {
+			t.Errorf("Compile(age %v) should fail", data)
+		}
+	}
+}
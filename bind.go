@@ -0,0 +1,232 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/28 14:22
+ * @Desc: 将验证通过的参数绑定到结构体
+ */
+
+const (
+	ValidateValBindFailed = "%s 格式不正确"
+	BindDstNotAllow       = "绑定目标必须是非空的结构体指针，不支持 %T"
+	BindTypeNotAllow      = "参数 %s 不支持绑定到 %s 类型"
+)
+
+// 绑定失败的翻译键
+const MsgBind = "bind"
+
+// 默认的数组分隔符
+const DefaultSeparator = ","
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// 参数验证并绑定到结构体，dst 为结构体指针
+// 字段通过 form、json 标签或字段名与 ValidationItem.Key 对应，空值不绑定
+func ValidateInto(params func(string) string, rules []ValidationItem, dst interface{}, opts ...Option) error {
+	schema, err := Compile(rules)
+	if err != nil {
+		return err
+	}
+	return schema.ValidateInto(params, dst, opts...)
+}
+
+// 按结构体标签进行参数验证并绑定到结构体，dst 为结构体指针
+func Bind(params func(string) string, dst interface{}, opts ...Option) error {
+	return BindSource(ParamsFunc(params), dst, opts...)
+}
+
+// 按结构体标签从参数来源读取参数验证并绑定到结构体，dst 为结构体指针
+func BindSource(src Source, dst interface{}, opts ...Option) error {
+	schema, err := StructSchema(dst)
+	if err != nil {
+		return err
+	}
+	return schema.ValidateSourceInto(src, dst, opts...)
+}
+
+// 参数验证并绑定到结构体，遇到第一个错误即返回
+func (s *Schema) ValidateInto(params func(string) string, dst interface{}, opts ...Option) error {
+	return s.ValidateSourceInto(ParamsFunc(params), dst, opts...)
+}
+
+// 从参数来源读取参数验证并绑定到结构体，遇到第一个错误即返回
+func (s *Schema) ValidateSourceInto(src Source, dst interface{}, opts ...Option) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf(BindDstNotAllow, dst)
+	}
+
+	o := newOptions(opts)
+	r, _, err := s.validateSource(o, src)
+	if err != nil {
+		return err
+	}
+	return s.bind(o, r.data, r.values, rv.Elem())
+}
+
+// 将参数逐个绑定到结构体字段
+// 字段与 StructItems 相同，匿名结构体指针为空时自动创建
+// 多值参数绑定到切片字段时直接使用 values 中的全部值，不按分隔符重新拆分
+func (s *Schema) bind(o *options, data map[string]string, values map[string][]string, rv reflect.Value) error {
+	for _, field := range structFields(rv.Type(), nil) {
+		f := field.StructField
+		key := structFieldKey(f)
+		val, ok := data[key]
+		if !ok || val == "" {
+			continue
+		}
+		item := s.item(key)
+		if item == nil {
+			continue
+		}
+		fv, ok := structFieldValue(rv, field.index)
+		if !ok {
+			continue
+		}
+		parseTime := itemTimeParser(&item.item, o.config.Loc())
+		var err error
+		if list, ok := values[key]; ok && fv.Kind() == reflect.Slice {
+			err = bindSlice(fv, list, itemSeparator(&item.item), parseTime)
+		} else {
+			err = bindValue(fv, val, itemSeparator(&item.item), parseTime)
+		}
+		if err != nil {
+			if _, unsupported := err.(*bindTypeError); unsupported {
+				return fmt.Errorf(BindTypeNotAllow, key, f.Type)
+			}
+			return o.translate(&FieldError{
+				Key:    key,
+				Name:   item.item.Name,
+				Rule:   MsgBind,
+				Param:  f.Type.String(),
+				Value:  val,
+				MsgKey: MsgBind,
+				Format: ValidateValBindFailed,
+				Err:    err,
+			})
+		}
+	}
+	return nil
+}
+
+// 按参数键查找验证项
+func (s *Schema) item(key string) *compiledItem {
+	for _, v := range s.items {
+		if v.item.Key == key {
+			return v
+		}
+	}
+	return nil
+}
+
+// 数组参数的分隔符，与 arrayInArray、distinct 规则保持一致
+func itemSeparator(item *ValidationItem) string {
+	for _, v := range item.Rules {
+		switch v.Rule {
+		case "arrayInArray":
+			if data, ok := v.Data.([]interface{}); ok && len(data) > 0 {
+				if sep, ok := data[0].(string); ok && sep != "" {
+					return sep
+				}
+			}
+		case "distinct":
+			if sep, ok := v.Data.(string); ok && sep != "" {
+				return sep
+			}
+		}
+	}
+	return DefaultSeparator
+}
+
+// 不支持绑定的字段类型
+type bindTypeError struct {
+	t reflect.Type
+}
+
+func (e *bindTypeError) Error() string {
+	return e.t.String()
+}
+
+// 按验证项的日期格式及 loc 时区解析时间，没有日期格式规则时为 DefaultData 格式
+func itemTimeParser(item *ValidationItem, loc *time.Location) func(string) (time.Time, error) {
+	f, _ := itemTimeFormat(item)
+	return func(val string) (time.Time, error) {
+		return f.parse(val, loc)
+	}
+}
+
+// 将字符串转换为字段类型并赋值，时间使用 parseTime 解析
+func bindValue(fv reflect.Value, val string, sep string, parseTime func(string) (time.Time, error)) error {
+	if fv.Type() == timeType {
+		t, err := parseTime(val)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(val)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(val, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(val, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(val, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Slice:
+		return bindSlice(fv, strings.Split(val, sep), sep, parseTime)
+	case reflect.Ptr:
+		ptr := reflect.New(fv.Type().Elem())
+		if err := bindValue(ptr.Elem(), val, sep, parseTime); err != nil {
+			return err
+		}
+		fv.Set(ptr)
+	default:
+		return &bindTypeError{fv.Type()}
+	}
+	return nil
+}
+
+// 将多个值逐个绑定到切片字段的元素
+func bindSlice(fv reflect.Value, parts []string, sep string, parseTime func(string) (time.Time, error)) error {
+	if fv.Type().Elem().Kind() == reflect.Slice {
+		return &bindTypeError{fv.Type()}
+	}
+	slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		if err := bindValue(slice.Index(i), part, sep, parseTime); err != nil {
+			return err
+		}
+	}
+	fv.Set(slice)
+	return nil
+}
@@ -0,0 +1,135 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/28 16:05
+ * @Desc:
+ */
+
+func TestValidateInto(t *testing.T) {
+	type request struct {
+		OrgId    int       `form:"orgId"`
+		UserId   int64     `form:"userId"`
+		Level    uint      `form:"level"`
+		Price    float64   `form:"price"`
+		IsSync   bool      `form:"isSync"`
+		Birthday time.Time `form:"birthday"`
+		Ids      []int     `form:"ids"`
+		Sort     []string  `form:"sort"`
+		Keywords *string   `form:"keywords"`
+		Status   string
+	}
+
+	rules := []ValidationItem{
+		{Key: "orgId", Name: "组织机构id", Rules: []ValidationRule{{Rule: "required"}, {Rule: "integer"}}},
+		{Key: "userId", Name: "用户id"},
+		{Key: "level", Name: "等级"},
+		{Key: "price", Name: "价格"},
+		{Key: "isSync", Name: "是否同步", Rules: []ValidationRule{{Rule: "bool"}}},
+		{Key: "birthday", Name: "生日"},
+		{Key: "ids", Name: "编号", Rules: []ValidationRule{{Rule: "distinct", Data: "|"}}},
+		{Key: "sort", Name: "排序", Rules: []ValidationRule{{Rule: "arrayInArray", Data: []interface{}{",", []string{"id", "name"}}}}},
+		{Key: "keywords", Name: "关键词"},
+		{Key: "Status", Name: "状态"},
+	}
+	params := map[string]string{
+		"orgId": "12", "userId": "9007199254740993", "level": "3", "price": "9.5", "isSync": "true",
+		"birthday": "2010-01-02", "ids": "1|2|3", "sort": "id,name", "keywords": "go", "Status": "ENABLED",
+	}
+
+	var req request
+	if err := ValidateInto(testParams(params), rules, &req); err != nil {
+		t.Fatalf("ValidateInto() failed. %v", err)
+	}
+	birthday, _ := time.ParseInLocation(DefaultData, "2010-01-02", DefaultConfig().Loc())
+	if req.OrgId != 12 || req.UserId != 9007199254740993 || req.Level != 3 || req.Price != 9.5 || !req.IsSync ||
+		!req.Birthday.Equal(birthday) || !reflect.DeepEqual(req.Ids, []int{1, 2, 3}) ||
+		!reflect.DeepEqual(req.Sort, []string{"id", "name"}) || *req.Keywords != "go" || req.Status != "ENABLED" {
+		t.Errorf("ValidateInto() unexpected result: %+v", req)
+	}
+
+	params["level"] = "-1"
+	err := ValidateInto(testParams(params), rules, &req)
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Key != "level" || fe.Rule != MsgBind {
+		t.Errorf("ValidateInto() should report conversion failure, got %v", err)
+	}
+
+	if err := ValidateInto(testParams(params), rules, req); err == nil {
+		t.Error("ValidateInto() should reject non-pointer dst.")
+	}
+}
+
+func TestBind(t *testing.T) {
+	var req testListRequest
+	params := map[string]string{"pageNum": "2", "pageSize": "10", "orgId": "1", "ids": "1,3", "price": "1.5"}
+	if err := Bind(testParams(params), &req); err != nil {
+		t.Fatalf("Bind() failed. %v", err)
+	}
+	if req.PageNum != 2 || req.PageSize != 10 || req.OrgId != "1" || !reflect.DeepEqual(req.Ids, []int{1, 3}) || req.Price != 1.5 {
+		t.Errorf("Bind() unexpected result: %+v", req)
+	}
+}
+
+// 未导出的结构体指针无法创建，测试使用导出的类型
+type BindAudit struct {
+	Operator string `form:"operator" validate:"required"`
+}
+
+type testBindPaging struct {
+	PageNum int `form:"pageNum" validate:"min=1"`
+}
+
+// 匿名结构体及结构体指针字段与验证时一样展开
+func TestBindEmbedded(t *testing.T) {
+	var req struct {
+		*BindAudit
+		testBindPaging
+		Skip string `form:"skip" validate:"-"`
+	}
+	params := testParams(map[string]string{"operator": "bool", "pageNum": "2", "skip": "x"})
+	if err := Bind(params, &req); err != nil {
+		t.Fatalf("Bind() failed. %v", err)
+	}
+	if req.BindAudit == nil || req.Operator != "bool" || req.PageNum != 2 || req.Skip != "" {
+		t.Errorf("Bind() = %+v %+v", req.BindAudit, req)
+	}
+}
+
+// 多值参数的每个值原样绑定，值中含有分隔符时不拆分
+func TestBindValues(t *testing.T) {
+	schema := MustCompile([]ValidationItem{
+		{Key: "tags", Name: "标签", Multiple: true, Rules: []ValidationRule{{Rule: "required"}}},
+		{Key: "name", Name: "名称"},
+	})
+	src := Values{"tags": {"c,d", "e"}, "name": {"go"}}
+
+	var req struct {
+		Tags []string `form:"tags"`
+		Name string   `form:"name"`
+	}
+	if err := schema.ValidateSourceInto(src, &req); err != nil {
+		t.Fatalf("ValidateSourceInto() failed. %v", err)
+	}
+	if !reflect.DeepEqual(req.Tags, []string{"c,d", "e"}) || req.Name != "go" {
+		t.Errorf("ValidateSourceInto() = %+v", req)
+	}
+
+	for _, n := range []int{0, 2} {
+		values, err := schema.ValidateAllValues(src, WithConcurrency(n))
+		if err != nil {
+			t.Fatalf("ValidateAllValues() failed. %v", err)
+		}
+		if !reflect.DeepEqual(values, Values{"tags": {"c,d", "e"}, "name": {"go"}}) {
+			t.Errorf("ValidateAllValues() = %v", values)
+		}
+	}
+}
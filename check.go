@@ -0,0 +1,111 @@
+package validator
+
+import (
+	"fmt"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/20 09:48
+ * @Desc: 验证规则配置检查
+ */
+
+const (
+	ConfigErrorFormat      = "参数 %s 的验证规则 %s 配置错误：%v"
+	ConfigRuleNotExists    = "规则不存在"
+	ConfigDataTypeNotAllow = "不支持 %T 类型的参数"
+	ConfigDataLength       = "参数长度必须为 %d"
+	ConfigDataMinLength    = "参数长度不能小于 %d"
+	ConfigDataNotInteger   = "参数 %q 不是整数"
+	ConfigDataRange        = "最小值 %v 不能大于最大值 %v"
+	ConfigDataEmpty        = "参数不能为空"
+	ConfigRegexpInvalid    = "正则表达式不合法：%v"
+	ConfigDefaultInvalid   = "默认值 %q 未通过验证：%v"
+)
+
+// 验证规则配置错误
+type ConfigError struct {
+	Key  string // 参数键
+	Rule string // 规则名称
+	Err  error  // 具体错误
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf(ConfigErrorFormat, e.Key, e.Rule, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// 检查验证项的配置，包括规则是否存在及规则参数是否合法，返回第一个 *ConfigError
+func CheckRules(rules []ValidationItem) error {
+	_, err := Compile(rules)
+	return err
+}
+
+// 内置规则的配置检查，需要预编译的规则见 builtinCompilers
+var builtinCheckers = map[string]RuleChecker{
+	"in":         checkStringList,
+	"filterChar": checkStringList,
+	"func":       checkFunc,
+	"distinct":   checkSeparator,
+}
+
+func checkStringList(data interface{}) error {
+	if _, ok := data.([]string); !ok {
+		return fmt.Errorf(ConfigDataTypeNotAllow, data)
+	}
+	return nil
+}
+
+func checkSeparator(data interface{}) error {
+	sep, ok := data.(string)
+	if !ok {
+		return fmt.Errorf(ConfigDataTypeNotAllow, data)
+	}
+	if sep == "" {
+		return fmt.Errorf(ConfigDataEmpty)
+	}
+	return nil
+}
+
+func checkArrayInArray(data interface{}) error {
+	list, ok := data.([]interface{})
+	if !ok {
+		return fmt.Errorf(ConfigDataTypeNotAllow, data)
+	}
+	if len(list) != 2 {
+		return fmt.Errorf(ConfigDataLength, 2)
+	}
+	if err := checkSeparator(list[0]); err != nil {
+		return err
+	}
+	switch list[1].(type) {
+	case []string, []int:
+	default:
+		return fmt.Errorf(ConfigDataTypeNotAllow, list[1])
+	}
+	return nil
+}
+
+func checkFunc(data interface{}) error {
+	switch rule := data.(type) {
+	case ValidationFuncRule:
+		if rule.Func == nil {
+			return fmt.Errorf(ConfigDataEmpty)
+		}
+	case ValidationContextFuncRule:
+		if rule.Func == nil {
+			return fmt.Errorf(ConfigDataEmpty)
+		}
+	case ValidationConfigFuncRule:
+		if rule == nil {
+			return fmt.Errorf(ConfigDataEmpty)
+		}
+	default:
+		return fmt.Errorf(ConfigDataTypeNotAllow, data)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/20 10:55
+ * @Desc:
+ */
+
+func TestCheckRules(t *testing.T) {
+	tests := []struct {
+		rule   ValidationRule
+		expect bool
+	}{
+		{ValidationRule{Rule: "required"}, true},
+		{ValidationRule{Rule: "requird"}, false},
+		{ValidationRule{Rule: "between", Data: []int{1, 100}}, true},
+		{ValidationRule{Rule: "between", Data: []int{1}}, false},
+		{ValidationRule{Rule: "between", Data: []int{100, 1}}, false},
+		{ValidationRule{Rule: "between", Data: []string{"1", "a"}}, false},
+		{ValidationRule{Rule: "between", Data: 1}, false},
+		{ValidationRule{Rule: "min", Data: 1.5}, true},
+		{ValidationRule{Rule: "min", Data: "a"}, false},
+		{ValidationRule{Rule: "in", Data: []string{"A"}}, true},
+		{ValidationRule{Rule: "in", Data: []int{1}}, false},
+		{ValidationRule{Rule: "arrayInArray", Data: []interface{}{",", []int{1}}}, true},
+		{ValidationRule{Rule: "arrayInArray", Data: []interface{}{",", []float64{1}}}, false},
+		{ValidationRule{Rule: "regexp", Data: ValidationMobileData()}, true},
+		{ValidationRule{Rule: "regexp", Data: ValidationRegexpRule{Regexp: "(a", Msg: "%s"}}, false},
+		{ValidationRule{Rule: "func", Data: ValidationFuncRule{Msg: "%s"}}, false},
+		{ValidationRule{Rule: "distinct", Data: ""}, false},
+	}
+
+	for _, test := range tests {
+		err := CheckRules([]ValidationItem{{Key: "k", Name: "n", Rules: []ValidationRule{test.rule}}})
+		if (err == nil) != test.expect {
+			t.Errorf("CheckRules(%s %v) = %v", test.rule.Rule, test.rule.Data, err)
+		}
+	}
+}
+
+func TestValidationConfigError(t *testing.T) {
+	rules := []ValidationItem{
+		{Key: "name", Name: "名称", Rules: []ValidationRule{{Rule: "required"}}},
+		{Key: "orgId", Name: "组织机构id", Rules: []ValidationRule{{Rule: "requird"}}},
+	}
+
+	_, key, err := Validation(testParams(map[string]string{"name": "a", "orgId": ""}), rules)
+	var ce *ConfigError
+	if !errors.As(err, &ce) || key != "orgId" || ce.Rule != "requird" {
+		t.Errorf("Validation() should report unknown rule, got key: %s, err: %v", key, err)
+	}
+
+	if _, err = ValidationAll(testParams(nil), rules); !errors.As(err, &ce) {
+		t.Errorf("ValidationAll() should report unknown rule, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package validator
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/24 14:10
+ * @Desc: 与其他参数比较的规则
+ *
+ * {Rule: "eqfield", Data: "password"}                  与 password 相等
+ * {Rule: "gtfield", Data: []string{"startAt", "date"}}  大于 startAt，并指定按日期比较
+ *
+ * 比较方式可指定为 number、date、string，eqfield、different 未指定时按字符串比较，
+ * 大小比较的规则未指定时两个值都是数字则按数字比较，都是日期则按日期比较，否则验证失败，按字符串比较需指定 string；
+ * same 始终按字符串比较
+ * 当前参数为空时不验证，其他参数为空时 eqfield、same 验证失败，大小比较的规则不验证
+ */
+
+const (
+	ValidateValEqField   = "%s 必须与 %s 相同"
+	ValidateValDifferent = "%s 不能与 %s 相同"
+	ValidateValGtField   = "%s 必须大于 %s"
+	ValidateValGteField  = "%s 必须大于等于 %s"
+	ValidateValLtField   = "%s 必须小于 %s"
+	ValidateValLteField  = "%s 必须小于等于 %s"
+)
+
+const (
+	MsgEqField   = "eqfield"
+	MsgDifferent = "different"
+	MsgGtField   = "gtfield"
+	MsgGteField  = "gtefield"
+	MsgLtField   = "ltfield"
+	MsgLteField  = "ltefield"
+)
+
+const (
+	CompareNumber = "number"
+	CompareDate   = "date"
+	CompareString = "string"
+)
+
+const ConfigCompareModeNotAllow = "不支持 %s 比较方式"
+
+// 按日期比较时支持的格式
+var compareDateLayouts = []string{DefaultData, DefaultDatetime, time.RFC3339}
+
+// 比较规则，accept 根据比较结果（-1、0、1）判断是否通过
+type compareRule struct {
+	key    string
+	format string
+	accept func(int) bool
+	mode   string // 固定的比较方式，为空时可由规则参数指定
+}
+
+func init() {
+	compares := map[string]compareRule{
+		"eqfield":   {MsgEqField, ValidateValEqField, func(n int) bool { return n == 0 }, ""},
+		"same":      {MsgEqField, ValidateValEqField, func(n int) bool { return n == 0 }, CompareString},
+		"different": {MsgDifferent, ValidateValDifferent, func(n int) bool { return n != 0 }, ""},
+		"gtfield":   {MsgGtField, ValidateValGtField, func(n int) bool { return n > 0 }, ""},
+		"gtefield":  {MsgGteField, ValidateValGteField, func(n int) bool { return n >= 0 }, ""},
+		"ltfield":   {MsgLtField, ValidateValLtField, func(n int) bool { return n < 0 }, ""},
+		"ltefield":  {MsgLteField, ValidateValLteField, func(n int) bool { return n <= 0 }, ""},
+	}
+	for name, v := range compares {
+		ruleRegistry[name] = &ruleEntry{compile: compileCompare(name, v)}
+	}
+}
+
+// 解析规则参数，Data 为其他参数键，或 []string{参数键, 比较方式}
+func parseCompareData(data interface{}) (string, string, error) {
+	switch d := data.(type) {
+	case string:
+		if d == "" {
+			return "", "", fmt.Errorf(ConfigDataEmpty)
+		}
+		return d, "", nil
+	case []string:
+		if len(d) != 2 {
+			return "", "", fmt.Errorf(ConfigDataLength, 2)
+		}
+		switch d[1] {
+		case CompareNumber, CompareDate, CompareString:
+			return d[0], d[1], nil
+		}
+		return "", "", fmt.Errorf(ConfigCompareModeNotAllow, d[1])
+	}
+	return "", "", fmt.Errorf(ConfigDataTypeNotAllow, data)
+}
+
+func compileCompare(name string, rule compareRule) ruleCompiler {
+	return func(data interface{}) (RuleFunc, error) {
+		other, mode, err := parseCompareData(data)
+		if err != nil {
+			return nil, err
+		}
+		if rule.mode != "" {
+			mode = rule.mode
+		}
+		ordered := name != "eqfield" && name != "same" && name != "different"
+		// 相等比较未指定比较方式时按字符串比较，避免 0100 与 100 被视为相同
+		if mode == "" && !ordered {
+			mode = CompareString
+		}
+
+		return func(c *RuleContext) error {
+			if c.Value == "" {
+				return nil
+			}
+			otherVal := c.Param(other)
+			if otherVal == "" && ordered {
+				return nil
+			}
+			n, ok := compareValues(c.Value, otherVal, mode, c.Config().Loc())
+			if ok && rule.accept(n) {
+				return nil
+			}
+			return newFieldError(c.Item, c.Index, c.Value, rule.key, rule.format, c.FieldName(other))
+		}, nil
+	}
+}
+
+// 比较两个值，返回 -1、0、1，指定的比较方式无法解析，或未指定时两个值不同为数字或日期时返回 false
+func compareValues(a, b, mode string, loc *time.Location) (int, bool) {
+	if mode == "" || mode == CompareNumber {
+		fa, errA := strconv.ParseFloat(a, 64)
+		fb, errB := strconv.ParseFloat(b, 64)
+		if errA == nil && errB == nil {
+			return compareFloat(fa, fb), true
+		}
+		if mode == CompareNumber {
+			return 0, false
+		}
+	}
+
+	if mode == "" || mode == CompareDate {
+		ta, okA := parseCompareDate(a, loc)
+		tb, okB := parseCompareDate(b, loc)
+		if okA && okB {
+			switch {
+			case ta.Before(tb):
+				return -1, true
+			case ta.After(tb):
+				return 1, true
+			}
+			return 0, true
+		}
+		return 0, false
+	}
+
+	switch {
+	case a < b:
+		return -1, true
+	case a > b:
+		return 1, true
+	}
+	return 0, true
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func parseCompareDate(val string, loc *time.Location) (time.Time, bool) {
+	for _, layout := range compareDateLayouts {
+		if t, err := time.ParseInLocation(layout, val, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,73 @@
+package validator
+
+import "testing"
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/24 16:45
+ * @Desc:
+ */
+
+func TestCompareRules(t *testing.T) {
+	tests := []struct {
+		rule   ValidationRule
+		value  string
+		other  string
+		expect bool
+	}{
+		{ValidationRule{Rule: "eqfield", Data: "other"}, "abc", "abc", true},
+		{ValidationRule{Rule: "eqfield", Data: "other"}, "abc", "", false},
+		{ValidationRule{Rule: "eqfield", Data: "other"}, "1.0", "1", false},
+		{ValidationRule{Rule: "eqfield", Data: "other"}, "0100", "100", false},
+		{ValidationRule{Rule: "eqfield", Data: []string{"other", CompareNumber}}, "1e2", "100", true},
+		{ValidationRule{Rule: "eqfield", Data: []string{"other", CompareDate}}, "2021-05-01", "2021-05-01 00:00:00", true},
+		{ValidationRule{Rule: "same", Data: "other"}, "1.0", "1", false},
+		{ValidationRule{Rule: "different", Data: "other"}, "a", "b", true},
+		{ValidationRule{Rule: "different", Data: "other"}, "a", "a", false},
+		{ValidationRule{Rule: "different", Data: "other"}, "0100", "100", true},
+		{ValidationRule{Rule: "different", Data: []string{"other", CompareNumber}}, "0100", "100", false},
+		{ValidationRule{Rule: "gtfield", Data: "other"}, "10", "9", true},
+		{ValidationRule{Rule: "gtfield", Data: "other"}, "9", "10", false},
+		{ValidationRule{Rule: "gtfield", Data: []string{"other", CompareString}}, "9", "10", true},
+		{ValidationRule{Rule: "gtfield", Data: "other"}, "10", "", true},
+		{ValidationRule{Rule: "gtfield", Data: "other"}, "9", "10x", false},
+		{ValidationRule{Rule: "gtfield", Data: "other"}, "b", "a", false},
+		{ValidationRule{Rule: "gtfield", Data: []string{"other", CompareString}}, "b", "a", true},
+		{ValidationRule{Rule: "ltfield", Data: "other"}, "2021-05-01", "20210502", false},
+		{ValidationRule{Rule: "gtefield", Data: "other"}, "2021-05-01", "2021-05-01", true},
+		{ValidationRule{Rule: "ltfield", Data: "other"}, "2021-04-30", "2021-05-01", true},
+		{ValidationRule{Rule: "ltfield", Data: "other"}, "2021-05-01 10:00:00", "2021-05-01 09:00:00", false},
+		{ValidationRule{Rule: "ltefield", Data: []string{"other", CompareDate}}, "2021-05-01", "abc", false},
+		{ValidationRule{Rule: "ltefield", Data: []string{"other", CompareNumber}}, "1", "2", true},
+	}
+
+	for _, test := range tests {
+		rules := []ValidationItem{
+			{Key: "other", Name: "其他"},
+			{Key: "v", Name: "值", Rules: []ValidationRule{test.rule}},
+		}
+		_, _, err := Validation(testParams(map[string]string{"v": test.value, "other": test.other}), rules)
+		if (err == nil) != test.expect {
+			t.Errorf("Validation(%s %v, %q, %q) = %v", test.rule.Rule, test.rule.Data, test.value, test.other, err)
+		}
+	}
+}
+
+func TestCompareMessage(t *testing.T) {
+	rules := []ValidationItem{
+		{Key: "startAt", Name: "开始时间"},
+		{Key: "endAt", Name: "结束时间", Rules: []ValidationRule{{Rule: "gtfield", Data: "startAt"}}},
+	}
+	params := testParams(map[string]string{"startAt": "2021-05-02", "endAt": "2021-05-01"})
+
+	if _, _, err := Validation(params, rules); err == nil || err.Error() != "结束时间 必须大于 开始时间" {
+		t.Errorf("Validation() = %v", err)
+	}
+	if _, _, err := Validation(params, rules, WithLocale("en-US")); err == nil || err.Error() != "结束时间 must be greater than 开始时间" {
+		t.Errorf("Validation() en = %v", err)
+	}
+	if _, err := Compile([]ValidationItem{{Key: "a", Rules: []ValidationRule{{Rule: "gtfield", Data: []string{"b", "money"}}}}}); err == nil {
+		t.Error("Compile() should reject unknown compare mode.")
+	}
+}
@@ -0,0 +1,242 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/22 15:30
+ * @Desc: 内置规则的预编译，提前解析规则参数
+ */
+
+// 预编译方法，检查规则参数并返回解析好参数的规则方法
+type ruleCompiler func(data interface{}) (RuleFunc, error)
+
+var builtinCompilers = map[string]ruleCompiler{
+	"between":      compileSize("between"),
+	"min":          compileSize("min"),
+	"max":          compileSize("max"),
+	"arrayInArray": compileArrayInArray,
+	"regexp":       compileRegexp,
+	"func":         compileFunc,
+}
+
+// 预解析的 between、min、max 规则参数
+type sizeRule struct {
+	rule   string     // between、min、max
+	kind   string     // int 数值、float 数值、string 字符串长度
+	ints   [2]int     // 整数或长度的上下限
+	floats [2]float64 // 浮点数的上下限
+}
+
+var sizeMessages = map[string]map[string][2]string{
+	"between": {
+		"int":    {MsgBetweenInt, ValidateValNotBetweenInt},
+		"float":  {MsgBetweenFloat, ValidateValNotBetweenFloat},
+		"string": {MsgBetweenStr, ValidateValNotBetweenStr},
+	},
+	"min": {
+		"int":    {MsgMinInt, ValidateValNotMinInt},
+		"float":  {MsgMinFloat, ValidateValNotMinFloat},
+		"string": {MsgMinStr, ValidateValNotMinStr},
+	},
+	"max": {
+		"int":    {MsgMaxInt, ValidateValNotMaxInt},
+		"float":  {MsgMaxFloat, ValidateValNotMaxFloat},
+		"string": {MsgMaxStr, ValidateValNotMaxStr},
+	},
+}
+
+// 解析规则参数，between 为长度为 2 的 []int、[]float64、[]string，min、max 为 int、float64、string
+// string 类型的参数表示字符串长度
+func parseSizeRule(rule string, data interface{}) (*sizeRule, error) {
+	size := &sizeRule{rule: rule}
+
+	if rule == "between" {
+		switch d := data.(type) {
+		case []int:
+			if len(d) != 2 {
+				return nil, fmt.Errorf(ConfigDataLength, 2)
+			}
+			size.kind, size.ints = "int", [2]int{d[0], d[1]}
+		case []float64:
+			if len(d) != 2 {
+				return nil, fmt.Errorf(ConfigDataLength, 2)
+			}
+			size.kind, size.floats = "float", [2]float64{d[0], d[1]}
+		case []string:
+			if len(d) != 2 {
+				return nil, fmt.Errorf(ConfigDataLength, 2)
+			}
+			size.kind = "string"
+			for i, v := range d {
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					return nil, fmt.Errorf(ConfigDataNotInteger, v)
+				}
+				size.ints[i] = n
+			}
+		default:
+			return nil, fmt.Errorf(ConfigDataTypeNotAllow, data)
+		}
+		if size.ints[0] > size.ints[1] {
+			return nil, fmt.Errorf(ConfigDataRange, size.ints[0], size.ints[1])
+		}
+		if size.floats[0] > size.floats[1] {
+			return nil, fmt.Errorf(ConfigDataRange, size.floats[0], size.floats[1])
+		}
+		return size, nil
+	}
+
+	// min 使用下限，max 使用上限
+	i := 0
+	if rule == "max" {
+		i = 1
+	}
+	switch d := data.(type) {
+	case int:
+		size.kind, size.ints[i] = "int", d
+	case float64:
+		size.kind, size.floats[i] = "float", d
+	case string:
+		n, err := strconv.Atoi(d)
+		if err != nil {
+			return nil, fmt.Errorf(ConfigDataNotInteger, d)
+		}
+		size.kind, size.ints[i] = "string", n
+	default:
+		return nil, fmt.Errorf(ConfigDataTypeNotAllow, data)
+	}
+	return size, nil
+}
+
+func (s *sizeRule) validate(rule *ValidationItem, index int, val string) error {
+	var ok bool
+	var args []interface{}
+
+	switch s.kind {
+	case "int":
+		valInt, err := strconv.Atoi(val)
+		ok = err == nil && s.inInts(valInt)
+		args = s.intArgs()
+	case "float":
+		valFloat, err := strconv.ParseFloat(val, 64)
+		ok = err == nil && s.inFloats(valFloat)
+		args = s.floatArgs()
+	case "string":
+		ok = s.inInts(utf8.RuneCountInString(val))
+		args = s.intArgs()
+	}
+	if ok {
+		return nil
+	}
+
+	msg := sizeMessages[s.rule][s.kind]
+	return newFieldError(rule, index, val, msg[0], msg[1], args...)
+}
+
+func (s *sizeRule) inInts(n int) bool {
+	return (s.rule == "max" || s.ints[0] <= n) && (s.rule == "min" || n <= s.ints[1])
+}
+
+func (s *sizeRule) inFloats(n float64) bool {
+	return (s.rule == "max" || s.floats[0] <= n) && (s.rule == "min" || n <= s.floats[1])
+}
+
+func (s *sizeRule) intArgs() []interface{} {
+	switch s.rule {
+	case "min":
+		return []interface{}{s.ints[0]}
+	case "max":
+		return []interface{}{s.ints[1]}
+	}
+	return []interface{}{s.ints[0], s.ints[1]}
+}
+
+func (s *sizeRule) floatArgs() []interface{} {
+	switch s.rule {
+	case "min":
+		return []interface{}{s.floats[0]}
+	case "max":
+		return []interface{}{s.floats[1]}
+	}
+	return []interface{}{s.floats[0], s.floats[1]}
+}
+
+func compileSize(rule string) ruleCompiler {
+	return func(data interface{}) (RuleFunc, error) {
+		size, err := parseSizeRule(rule, data)
+		if err != nil {
+			return nil, err
+		}
+		return func(c *RuleContext) error {
+			if c.Value == "" {
+				return nil
+			}
+			return size.validate(c.Item, c.Index, c.Value)
+		}, nil
+	}
+}
+
+func compileArrayInArray(data interface{}) (RuleFunc, error) {
+	if err := checkArrayInArray(data); err != nil {
+		return nil, err
+	}
+	sep := data.([]interface{})[0].(string)
+	list := data.([]interface{})[1]
+
+	_, isInt := list.([]int)
+	allowed := map[string]bool{}
+	switch l := list.(type) {
+	case []string:
+		for _, v := range l {
+			allowed[v] = true
+		}
+	case []int:
+		for _, v := range l {
+			allowed[strconv.Itoa(v)] = true
+		}
+	}
+
+	return func(c *RuleContext) error {
+		if c.Value == "" {
+			return nil
+		}
+		for _, v := range strings.Split(c.Value, sep) {
+			if isInt {
+				// 与 ValidationArrayInArray 保持一致，整数按数值比较
+				if n, err := strconv.Atoi(v); err == nil {
+					v = strconv.Itoa(n)
+				}
+			}
+			if !allowed[v] {
+				return newFieldError(c.Item, c.Index, c.Value, MsgArrayInArray, ValidateValArrayNotInArray, list)
+			}
+		}
+		return nil
+	}, nil
+}
+
+func compileRegexp(data interface{}) (RuleFunc, error) {
+	rule, ok := data.(ValidationRegexpRule)
+	if !ok {
+		return nil, fmt.Errorf(ConfigDataTypeNotAllow, data)
+	}
+	re, err := regexp.Compile(rule.Regexp)
+	if err != nil {
+		return nil, fmt.Errorf(ConfigRegexpInvalid, err)
+	}
+
+	return func(c *RuleContext) error {
+		if c.Value != "" && !re.MatchString(c.Value) {
+			return newFieldError(c.Item, c.Index, c.Value, rule.Msg, rule.Msg)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"context"
+	"sync"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/7 10:40
+ * @Desc: 并发执行顶层验证项，适用于含有多个需要访问外部资源的规则的验证项集合
+ *
+ * schema.ValidateAll(params, validator.WithConcurrency(4))
+ *
+ * 各顶层验证项（含通配路径展开后的每个参数）互不依赖，由不超过 n 个 goroutine 并发执行，
+ * 对象及数组参数的子验证项仍在同一 goroutine 中按顺序执行。参数来源需支持并发读取。
+ * 验证结果与按顺序执行相同：错误按验证项顺序排列，遇到第一个错误即返回时返回顺序最靠前的错误，
+ * 某个参数中止验证后，取消排在其后的参数的 context，排在其前的参数继续执行
+ */
+
+// 设置并发执行顶层验证项的 goroutine 数量，小于等于 1 时按顺序执行
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		o.concurrency = n
+	}
+}
+
+// 并发执行的单个顶层参数
+type concurrentJob struct {
+	v      *compiledItem
+	path   string
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	errs *ItemErrors
+	r    *runner
+}
+
+// 并发执行全部验证项，结果与 run 按顺序执行相同
+func (s *Schema) runConcurrent(o *options, src Source, failFast bool) (*runner, ValidationErrors) {
+	r := newRunner(o, src, failFast)
+	var jobs []*concurrentJob
+	for _, v := range s.items {
+		for _, path := range r.expand(v.item.Key) {
+			ctx, cancel := context.WithCancel(o.ctx)
+			jobs = append(jobs, &concurrentJob{v: v, path: path, ctx: ctx, cancel: cancel})
+		}
+	}
+	defer func() {
+		for _, job := range jobs {
+			job.cancel()
+		}
+	}()
+
+	var mu sync.Mutex
+	stopAt := len(jobs) // 第一个中止验证的参数下标
+	sc := &scope{src: src, siblings: s.items}
+	queue := make(chan int)
+	var wg sync.WaitGroup
+
+	workers := o.concurrency
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				mu.Lock()
+				skip := i > stopAt
+				mu.Unlock()
+				if skip {
+					continue
+				}
+
+				job := jobs[i]
+				jo := *o
+				jo.ctx = job.ctx
+				jr := newRunner(&jo, src, failFast)
+				job.errs = jr.item(sc, job.v, job.path, nil)
+				job.r = jr
+				if !jr.stop {
+					continue
+				}
+
+				mu.Lock()
+				if i < stopAt {
+					stopAt = i
+					for _, next := range jobs[i+1:] {
+						next.cancel()
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range jobs {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	var errs ValidationErrors
+	for i := 0; i < len(jobs) && i <= stopAt; i++ {
+		job := jobs[i]
+		if job.r.err != nil {
+			r.err = job.r.err
+			return r, nil
+		}
+		for k, v := range job.r.data {
+			r.data[k] = v
+		}
+		for k, v := range job.r.values {
+			r.values[k] = v
+		}
+		if job.errs != nil {
+			errs = append(errs, *job.errs)
+		}
+	}
+	return r, errs
+}
@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/7 16:05
+ * @Desc:
+ */
+
+// 模拟耗时的远程查询，值为 slow 时等待到 context 取消，值为 bad 时延迟后验证不通过
+func remoteCheck(running, maxRunning *int32) func(ctx context.Context, val string) error {
+	var mu sync.Mutex
+	return func(ctx context.Context, val string) error {
+		n := atomic.AddInt32(running, 1)
+		defer atomic.AddInt32(running, -1)
+		mu.Lock()
+		if n > *maxRunning {
+			*maxRunning = n
+		}
+		mu.Unlock()
+
+		switch val {
+		case "slow":
+			<-ctx.Done()
+			return ctx.Err()
+		case "bad":
+			time.Sleep(20 * time.Millisecond)
+			return InvalidValue
+		case "fail":
+			return InvalidValue
+		}
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}
+}
+
+func concurrentRules(fn func(ctx context.Context, val string) error, n int) []ValidationItem {
+	rules := make([]ValidationItem, n)
+	for i := range rules {
+		key := "v" + strconv.Itoa(i)
+		rules[i] = ValidationItem{Key: key, Name: key, Rules: []ValidationRule{
+			{Rule: "func", Data: ValidationContextFuncRule{Func: fn, Msg: "%s 不可用"}},
+		}}
+	}
+	return rules
+}
+
+func TestConcurrentValidateAll(t *testing.T) {
+	var running, maxRunning int32
+	schema := MustCompile(concurrentRules(remoteCheck(&running, &maxRunning), 8))
+	params := testParams(map[string]string{"v0": "ok", "v1": "fail", "v2": "bad", "v3": "ok", "v5": "fail", "v6": "bad"})
+
+	expectData, expectErr := schema.ValidateAll(params)
+	data, err := schema.ValidateAll(params, WithConcurrency(3))
+	if !reflect.DeepEqual(data, expectData) || !reflect.DeepEqual(err.(ValidationErrors).Fields(), expectErr.(ValidationErrors).Fields()) {
+		t.Errorf("ValidateAll() = %v %v, expect %v %v", data, err, expectData, expectErr)
+	}
+	if keys := err.(ValidationErrors).Flatten(); len(keys) != 4 || keys[0].Key != "v1" || keys[3].Key != "v6" {
+		t.Errorf("ValidateAll() errors not in item order: %v", err)
+	}
+	if maxRunning > 3 || maxRunning < 2 {
+		t.Errorf("ValidateAll() max running = %d", maxRunning)
+	}
+}
+
+// 遇到第一个错误即返回时，返回顺序最靠前的错误，并取消排在其后的参数
+func TestConcurrentFailFast(t *testing.T) {
+	var running, maxRunning int32
+	schema := MustCompile(concurrentRules(remoteCheck(&running, &maxRunning), 4))
+	params := testParams(map[string]string{"v0": "ok", "v1": "bad", "v2": "fail", "v3": "slow"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, key, err := schema.Validate(params, WithConcurrency(4), WithContext(ctx))
+	var fe *FieldError
+	if key != "v1" || !errors.As(err, &fe) {
+		t.Errorf("Validate() = %s %v", key, err)
+	}
+	if ctx.Err() != nil {
+		t.Error("Validate() slow item not canceled")
+	}
+}
+
+func TestConcurrentRuleError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var running, maxRunning int32
+	schema := MustCompile(concurrentRules(remoteCheck(&running, &maxRunning), 4))
+	_, err := schema.ValidateAll(testParams(map[string]string{"v0": "ok"}), WithConcurrency(2), WithContext(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ValidateAll() canceled = %v", err)
+	}
+}
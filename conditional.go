@@ -0,0 +1,143 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/20 10:05
+ * @Desc: 依赖其他参数的条件规则
+ *
+ * {Rule: "required_if", Data: []string{"certType", "ID"}}             certType 为 ID 时不能为空，可以有多个值
+ * {Rule: "required_unless", Data: []string{"certType", "PASSPORT"}}   certType 不为 PASSPORT 时不能为空
+ * {Rule: "required_with", Data: []string{"mobile", "email"}}          mobile、email 任意一个不为空时不能为空
+ * {Rule: "required_with_all", Data: []string{"mobile", "email"}}      mobile、email 都不为空时不能为空
+ * {Rule: "required_without", Data: []string{"mobile", "email"}}       mobile、email 任意一个为空时不能为空
+ * {Rule: "exclude_if", Data: []string{"type", "NONE"}}                type 为 NONE 时跳过剩余规则，且不出现在验证结果中
+ */
+
+const (
+	ValidateValRequiredIf      = "%s 在 %s 为 %s 时不能为空"
+	ValidateValRequiredUnless  = "%s 在 %s 不为 %s 时不能为空"
+	ValidateValRequiredWith    = "%s 在 %s 不为空时不能为空"
+	ValidateValRequiredWithAll = "%s 在 %s 都不为空时不能为空"
+	ValidateValRequiredWithout = "%s 在 %s 为空时不能为空"
+)
+
+const (
+	MsgRequiredIf      = "required_if"
+	MsgRequiredUnless  = "required_unless"
+	MsgRequiredWith    = "required_with"
+	MsgRequiredWithAll = "required_with_all"
+	MsgRequiredWithout = "required_without"
+)
+
+// 条件规则中多个参数名称或值的连接符
+const conditionSeparator = "/"
+
+func init() {
+	conditionals := map[string]struct {
+		fn       RuleFunc
+		minCount int
+	}{
+		"required_if":       {requiredIf(true), 2},
+		"required_unless":   {requiredIf(false), 2},
+		"required_with":     {requiredWith(MsgRequiredWith, ValidateValRequiredWith, anyFilled), 1},
+		"required_with_all": {requiredWith(MsgRequiredWithAll, ValidateValRequiredWithAll, allFilled), 1},
+		"required_without":  {requiredWith(MsgRequiredWithout, ValidateValRequiredWithout, anyEmpty), 1},
+		"exclude_if":        {excludeIf, 2},
+	}
+	for name, v := range conditionals {
+		ruleRegistry[name] = &ruleEntry{fn: v.fn, check: checkFieldList(v.minCount), list: true}
+	}
+}
+
+// 参数为 []string，且至少有 minCount 个元素
+func checkFieldList(minCount int) RuleChecker {
+	return func(data interface{}) error {
+		list, ok := data.([]string)
+		if !ok {
+			return fmt.Errorf(ConfigDataTypeNotAllow, data)
+		}
+		if len(list) < minCount {
+			return fmt.Errorf(ConfigDataMinLength, minCount)
+		}
+		return nil
+	}
+}
+
+// 同级参数的值是否在给定的值中
+func paramIn(c *RuleContext, data []string) bool {
+	other := c.Param(data[0])
+	for _, v := range data[1:] {
+		if other == v {
+			return true
+		}
+	}
+	return false
+}
+
+// required_if、required_unless
+func requiredIf(equal bool) RuleFunc {
+	key, format := MsgRequiredIf, ValidateValRequiredIf
+	if !equal {
+		key, format = MsgRequiredUnless, ValidateValRequiredUnless
+	}
+
+	return func(c *RuleContext) error {
+		data := c.Data().([]string)
+		if c.Value != "" || paramIn(c, data) != equal {
+			return nil
+		}
+		return newFieldError(c.Item, c.Index, c.Value, key, format,
+			c.FieldName(data[0]), strings.Join(data[1:], conditionSeparator))
+	}
+}
+
+func anyFilled(c *RuleContext, keys []string) bool {
+	for _, k := range keys {
+		if c.Param(k) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func allFilled(c *RuleContext, keys []string) bool {
+	for _, k := range keys {
+		if c.Param(k) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func anyEmpty(c *RuleContext, keys []string) bool {
+	return !allFilled(c, keys)
+}
+
+// required_with、required_with_all、required_without
+func requiredWith(key, format string, cond func(*RuleContext, []string) bool) RuleFunc {
+	return func(c *RuleContext) error {
+		keys := c.Data().([]string)
+		if c.Value != "" || !cond(c, keys) {
+			return nil
+		}
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = c.FieldName(k)
+		}
+		return newFieldError(c.Item, c.Index, c.Value, key, format, strings.Join(names, conditionSeparator))
+	}
+}
+
+// exclude_if
+func excludeIf(c *RuleContext) error {
+	if paramIn(c, c.Data().([]string)) {
+		return ExcludeField
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package validator
+
+import "testing"
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/20 15:32
+ * @Desc:
+ */
+
+func TestConditionalRules(t *testing.T) {
+	tests := []struct {
+		rule   ValidationRule
+		params map[string]string
+		expect bool
+	}{
+		{ValidationRule{Rule: "required_if", Data: []string{"certType", "ID", "HK"}}, map[string]string{"certType": "ID"}, false},
+		{ValidationRule{Rule: "required_if", Data: []string{"certType", "ID", "HK"}}, map[string]string{"certType": "HK", "v": "1"}, true},
+		{ValidationRule{Rule: "required_if", Data: []string{"certType", "ID"}}, map[string]string{"certType": "PASSPORT"}, true},
+		{ValidationRule{Rule: "required_unless", Data: []string{"certType", "PASSPORT"}}, map[string]string{"certType": "ID"}, false},
+		{ValidationRule{Rule: "required_unless", Data: []string{"certType", "PASSPORT"}}, map[string]string{"certType": "PASSPORT"}, true},
+		{ValidationRule{Rule: "required_with", Data: []string{"mobile", "email"}}, map[string]string{"email": "a@b.c"}, false},
+		{ValidationRule{Rule: "required_with", Data: []string{"mobile", "email"}}, map[string]string{}, true},
+		{ValidationRule{Rule: "required_with_all", Data: []string{"mobile", "email"}}, map[string]string{"email": "a@b.c"}, true},
+		{ValidationRule{Rule: "required_with_all", Data: []string{"mobile", "email"}}, map[string]string{"mobile": "1", "email": "a@b.c"}, false},
+		{ValidationRule{Rule: "required_without", Data: []string{"mobile", "email"}}, map[string]string{"mobile": "1"}, false},
+		{ValidationRule{Rule: "required_without", Data: []string{"mobile", "email"}}, map[string]string{"mobile": "1", "email": "a@b.c"}, true},
+	}
+
+	for _, test := range tests {
+		rules := []ValidationItem{{Key: "v", Name: "值", Rules: []ValidationRule{test.rule}}}
+		_, _, err := Validation(testParams(test.params), rules)
+		if (err == nil) != test.expect {
+			t.Errorf("Validation(%s %v, %v) = %v", test.rule.Rule, test.rule.Data, test.params, err)
+		}
+	}
+}
+
+func TestRequiredIfMessage(t *testing.T) {
+	rules := []ValidationItem{
+		{Key: "certType", Name: "证件类型"},
+		{Key: "idCardCode", Name: "身份证号码", Rules: []ValidationRule{
+			{Rule: "required_if", Data: []string{"certType", "ID"}},
+			{Rule: "func", Data: ValidationIdCardCodeData()},
+		}},
+	}
+
+	_, _, err := Validation(testParams(map[string]string{"certType": "ID"}), rules)
+	if err == nil || err.Error() != "身份证号码 在 证件类型 为 ID 时不能为空" {
+		t.Errorf("Validation() = %v", err)
+	}
+	_, _, err = Validation(testParams(map[string]string{"certType": "ID"}), rules, WithLocale("en"))
+	if err == nil || err.Error() != "身份证号码 is required when 证件类型 is ID" {
+		t.Errorf("Validation() en = %v", err)
+	}
+}
+
+func TestExcludeIf(t *testing.T) {
+	rules := []ValidationItem{
+		{Key: "type", Name: "类型"},
+		{Key: "value", Name: "值", Rules: []ValidationRule{
+			{Rule: "exclude_if", Data: []string{"type", "NONE"}},
+			{Rule: "required"},
+		}},
+	}
+
+	data, _, err := Validation(testParams(map[string]string{"type": "NONE", "value": "x"}), rules)
+	if err != nil {
+		t.Fatalf("Validation() failed. %v", err)
+	}
+	if _, ok := data["value"]; ok {
+		t.Errorf("Validation() should exclude value, got %v", data)
+	}
+	if _, _, err = Validation(testParams(map[string]string{"type": "TEXT"}), rules); err == nil {
+		t.Error("Validation() should require value.")
+	}
+}
+
+func TestConditionalNested(t *testing.T) {
+	src, _ := ParseJSON([]byte(`{"items": [{"type": "gift", "sku": ""}, {"type": "goods", "sku": ""}]}`))
+	schema := MustCompile([]ValidationItem{
+		{Key: "items", Each: &ValidationItem{Children: []ValidationItem{
+			{Key: "type", Name: "类型"},
+			{Key: "sku", Name: "商品编号", Rules: []ValidationRule{{Rule: "required_unless", Data: []string{"type", "gift"}}}},
+		}}},
+	})
+
+	_, key, _ := schema.ValidateSource(src)
+	if key != "items[1].sku" {
+		t.Errorf("ValidateSource() should resolve sibling in the same element, got %s", key)
+	}
+}
+
+type testCertRequest struct {
+	CertType string `form:"certType" name:"证件类型" validate:"in=ID HK PASSPORT"`
+	CertNo   string `form:"certNo" name:"证件号码" validate:"required_if=certType ID HK"`
+	Mobile   string `form:"mobile" name:"手机号" validate:"required_without=email"`
+	Email    string `form:"email" name:"邮箱" validate:"required_with=mobile"`
+	Remark   string `form:"remark" name:"备注" validate:"exclude_if=certType PASSPORT,max=10"`
+}
+
+// 结构体标签中的条件规则
+func TestConditionalStructTags(t *testing.T) {
+	tests := []struct {
+		params map[string]string
+		key    string
+	}{
+		{map[string]string{"certType": "ID", "certNo": "1", "mobile": "1", "email": "a@b.c"}, ""},
+		{map[string]string{"certType": "HK", "mobile": "1", "email": "a@b.c"}, "certNo"},
+		{map[string]string{"certType": "PASSPORT"}, "mobile"},
+		{map[string]string{"certType": "PASSPORT", "mobile": "1"}, "email"},
+		{map[string]string{"certType": "PASSPORT", "email": "a@b.c", "remark": "more than ten"}, ""},
+		{map[string]string{"certType": "ID", "certNo": "1", "email": "a@b.c", "remark": "more than ten"}, "remark"},
+	}
+
+	for _, test := range tests {
+		_, key, err := ValidationStruct(testParams(test.params), &testCertRequest{})
+		if key != test.key || (err == nil) != (test.key == "") {
+			t.Errorf("ValidationStruct(%v) = %s %v, want %s", test.params, key, err, test.key)
+		}
+	}
+}
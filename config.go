@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"sync"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/15 10:05
+ * @Desc: 验证配置，时间相关的规则及参数绑定使用配置中的时区和时钟
+ *
+ * 单次验证使用 WithConfig 设置，未设置时使用 SetDefaultConfig 设置的默认配置
+ * ValidationBirthdayData 等 func 规则没有验证选项，使用默认配置；
+ * 需要使用单次验证的配置时，Data 使用 ValidationConfigFuncRule，如 ValidationConfigFuncRule((*Config).BirthdayData)，
+ * 标签及规则字符串中的 func=birthday、func=date、func=startAt 使用该方式
+ */
+
+// 时钟，用于获取当前时间，测试时可替换为固定时间
+type Clock interface {
+	Now() time.Time
+}
+
+// 将 func() time.Time 转换为 Clock
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// 系统时钟
+var SystemClock Clock = ClockFunc(time.Now)
+
+// 验证配置，创建后不应修改，需要不同配置时创建新的 Config
+type Config struct {
+	Location *time.Location // 时区，为空时为 DefaultLocal
+	Clock    Clock          // 时钟，为空时为 SystemClock
+}
+
+var (
+	configMu      sync.RWMutex
+	defaultConfig = NewConfig()
+	defaultLoc    = loadLocation(DefaultLocal)
+)
+
+// 创建使用 DefaultLocal 时区及系统时钟的配置
+func NewConfig() *Config {
+	return &Config{Location: loadLocation(DefaultLocal), Clock: SystemClock}
+}
+
+// 默认配置
+func DefaultConfig() *Config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return defaultConfig
+}
+
+// 设置默认配置，c 为空时恢复为 NewConfig 创建的配置
+func SetDefaultConfig(c *Config) {
+	if c == nil {
+		c = NewConfig()
+	}
+	configMu.Lock()
+	defer configMu.Unlock()
+	defaultConfig = c
+}
+
+// 按验证使用的配置生成的 func 规则
+type ValidationConfigFuncRule func(c *Config) ValidationFuncRule
+
+// 设置单次验证使用的配置
+func WithConfig(c *Config) Option {
+	return func(o *options) {
+		o.config = c
+	}
+}
+
+// 配置的时区
+func (c *Config) Loc() *time.Location {
+	if c == nil || c.Location == nil {
+		return defaultLoc
+	}
+	return c.Location
+}
+
+// 配置时区的当前时间
+func (c *Config) Now() time.Time {
+	clock := SystemClock
+	if c != nil && c.Clock != nil {
+		clock = c.Clock
+	}
+	return clock.Now().In(c.Loc())
+}
+
+// 当前规则使用的配置
+func (c *RuleContext) Config() *Config {
+	if c.config == nil {
+		return DefaultConfig()
+	}
+	return c.config
+}
+
+func configFunc(rule ValidationConfigFuncRule) RuleFunc {
+	return func(c *RuleContext) error {
+		if c.Value == "" {
+			return nil
+		}
+		data := rule(c.Config())
+		if !data.Func(c.Value) {
+			return newFieldError(c.Item, c.Index, c.Value, data.Msg, data.Msg)
+		}
+		return nil
+	}
+}
+
+// 加载时区，系统缺少时区数据时 DefaultLocal 使用东八区固定时区
+func loadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		if name == DefaultLocal {
+			return time.FixedZone("CST", 8*60*60)
+		}
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,111 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/15 15:30
+ * @Desc:
+ */
+
+// 固定在 2021-01-01 00:30 东八区的配置
+func frozenConfig() *Config {
+	loc := time.FixedZone("CST", 8*60*60)
+	now := time.Date(2021, 1, 1, 0, 30, 0, 0, loc)
+	return &Config{Location: loc, Clock: ClockFunc(func() time.Time { return now })}
+}
+
+func TestConfigNow(t *testing.T) {
+	cfg := frozenConfig()
+	if now := cfg.Now(); now.Year() != 2021 || now.Location() != cfg.Location {
+		t.Errorf("Config.Now() = %v", now)
+	}
+	// 同一时刻在 UTC 仍为 2020 年
+	utc := &Config{Location: time.UTC, Clock: cfg.Clock}
+	if now := utc.Now(); now.Year() != 2020 {
+		t.Errorf("Config.Now() UTC = %v", now)
+	}
+
+	var empty *Config
+	if empty.Loc() == nil || (&Config{}).Now().IsZero() {
+		t.Error("Config zero value should fall back to defaults")
+	}
+}
+
+func TestConfigFuncRules(t *testing.T) {
+	cfg := frozenConfig()
+	tests := []struct {
+		rule   ValidationFuncRule
+		in     string
+		expect bool
+	}{
+		{cfg.BirthdayData(), "2019-12-31", true},
+		{cfg.BirthdayData(), "2021-01-01", false},
+		{cfg.StartAtData(), "1609431000", true},  // 2021-01-01 00:10 +08:00
+		{cfg.StartAtData(), "1609433400", false}, // 2021-01-01 00:50 +08:00
+		{cfg.DateData(), "2021-02-30", false},
+	}
+	for _, test := range tests {
+		if ok := test.rule.Func(test.in); ok != test.expect {
+			t.Errorf("%s Func(%s) = %v", test.rule.Msg, test.in, ok)
+		}
+	}
+
+	SetDefaultConfig(cfg)
+	defer SetDefaultConfig(nil)
+	if !ValidationStartAtData().Func("1609431000") || ValidationStartAtData().Func("1609433400") {
+		t.Error("ValidationStartAtData() should use the default config")
+	}
+}
+
+// 标签及规则字符串中依赖当前时间的 func 规则使用单次验证的配置
+func TestConfigFuncRuleOption(t *testing.T) {
+	past := &Config{Location: time.UTC, Clock: ClockFunc(func() time.Time { return time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC) })}
+	tests := []struct {
+		rules string
+		in    string
+	}{
+		{"func:birthday", "2010-01-01"},
+		{"func:startAt", "946771200"}, // 2000-01-02 00:00 UTC
+	}
+	for _, test := range tests {
+		rules := []ValidationItem{{Key: "v", Name: "v", Rules: MustParseRules(test.rules)}}
+		params := testParams(map[string]string{"v": test.in})
+		if _, err := ValidationAll(params, rules); err != nil {
+			t.Errorf("ValidationAll(%s) failed. %v", test.rules, err)
+		}
+		if _, err := ValidationAll(params, rules, WithConfig(past)); err == nil {
+			t.Errorf("ValidationAll(%s) should use WithConfig", test.rules)
+		}
+	}
+
+	type form struct {
+		Birthday string `form:"birthday" validate:"func=birthday"`
+	}
+	var f form
+	if err := Bind(testParams(map[string]string{"birthday": "2010-01-01"}), &f, WithConfig(past)); err == nil {
+		t.Error("Bind() should use WithConfig")
+	}
+
+	rules := []ValidationItem{{Key: "v", Name: "v", Rules: []ValidationRule{{Rule: "func", Data: ValidationConfigFuncRule((*Config).BirthdayData)}}}}
+	if _, err := ValidationAll(testParams(map[string]string{"v": "2010-01-01"}), rules, WithConfig(past)); err == nil {
+		t.Error("ValidationConfigFuncRule should use WithConfig")
+	}
+}
+
+func TestConfigBind(t *testing.T) {
+	type form struct {
+		Birthday time.Time `form:"birthday"`
+	}
+	var f form
+	if err := Bind(testParams(map[string]string{"birthday": "2010-01-02"}), &f, WithConfig(&Config{Location: time.UTC})); err != nil {
+		t.Fatalf("Bind() failed. %v", err)
+	}
+	if f.Birthday.Location() != time.UTC || f.Birthday.Hour() != 0 {
+		t.Errorf("Bind() birthday = %v", f.Birthday)
+	}
+}
@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/3 10:15
+ * @Desc: 需要访问数据库等外部资源的验证规则，通过 WithContext 传入 context 控制取消及超时
+ *
+ * {Rule: "func", Data: ValidationContextFuncRule{Func: usernameNotExists, Msg: "%s 已被使用"}}
+ *
+ * 规则返回 InvalidValue 表示参数验证不通过，返回其他错误表示规则执行出错（如数据库不可用），
+ * 执行出错时中止验证并返回 *RuleError，不再作为参数验证错误返回
+ */
+
+const (
+	RuleErrorFormat = "参数 %s 的验证规则 %s 执行出错：%v"
+)
+
+// 参数验证不通过，ValidationContextFuncRule.Func 返回该错误或包装了该错误的错误
+var InvalidValue = errors.New("invalid value")
+
+// 使用 context 的自定义验证方法
+type ValidationContextFuncRule struct {
+	Func func(ctx context.Context, val string) error
+	Msg  string
+}
+
+// 规则执行错误，与参数是否合法无关，如数据库不可用、context 已取消或超时
+type RuleError struct {
+	Key  string // 参数键
+	Rule string // 规则名称
+	Err  error  // 具体错误
+}
+
+func (e *RuleError) Error() string {
+	return fmt.Sprintf(RuleErrorFormat, e.Key, e.Rule, e.Err)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+// 设置验证使用的 context，规则通过 RuleContext.Context 获取
+func WithContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+// 当前验证的 context，未设置时为 context.Background()
+func (c *RuleContext) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// 构造当前规则的执行错误，规则返回后中止验证
+func (c *RuleContext) Abort(err error) error {
+	return &RuleError{Key: c.Item.Key, Rule: c.Rule().Rule, Err: err}
+}
+
+// 规则返回的错误是否为执行错误，context 取消或超时同样视为执行错误
+func asRuleError(c *RuleContext, err error) (*RuleError, bool) {
+	var re *RuleError
+	if errors.As(err, &re) {
+		return re, true
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return c.Abort(err).(*RuleError), true
+	}
+	return nil, false
+}
+
+// func 规则的预编译方法，Data 可以是 ValidationFuncRule、ValidationContextFuncRule 或 ValidationConfigFuncRule
+func compileFunc(data interface{}) (RuleFunc, error) {
+	switch rule := data.(type) {
+	case ValidationContextFuncRule:
+		return contextFunc(rule), nil
+	case ValidationConfigFuncRule:
+		return configFunc(rule), nil
+	}
+	return wrapRule(ValidationFunc), nil
+}
+
+func contextFunc(rule ValidationContextFuncRule) RuleFunc {
+	return func(c *RuleContext) error {
+		if c.Value == "" {
+			return nil
+		}
+		ctx := c.Context()
+		if err := ctx.Err(); err != nil {
+			return c.Abort(err)
+		}
+		err := rule.Func(ctx, c.Value)
+		switch {
+		case err == nil:
+			return nil
+		case errors.Is(err, InvalidValue):
+			return newFieldError(c.Item, c.Index, c.Value, rule.Msg, rule.Msg)
+		}
+		return c.Abort(err)
+	}
+}
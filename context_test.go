@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/3 15:20
+ * @Desc:
+ */
+
+var errDBUnavailable = errors.New("db unavailable")
+
+// 模拟查询数据库的验证方法
+func usernameNotExists(ctx context.Context, val string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	switch val {
+	case "admin":
+		return InvalidValue
+	case "down":
+		return errDBUnavailable
+	}
+	return nil
+}
+
+func contextRules() []ValidationItem {
+	return []ValidationItem{
+		{Key: "username", Name: "用户名", Rules: []ValidationRule{
+			{Rule: "required"},
+			{Rule: "func", Data: ValidationContextFuncRule{Func: usernameNotExists, Msg: "%s 已被使用"}},
+		}},
+		{Key: "nickname", Name: "昵称", Rules: []ValidationRule{{Rule: "required"}}},
+	}
+}
+
+func TestContextFunc(t *testing.T) {
+	schema := MustCompile(contextRules())
+
+	if _, err := schema.ValidateAll(testParams(map[string]string{"username": "bool", "nickname": "b"})); err != nil {
+		t.Errorf("ValidateAll() failed. %v", err)
+	}
+
+	_, err := schema.ValidateAll(testParams(map[string]string{"username": "admin"}))
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Errors[0].Error() != "用户名 已被使用" {
+		t.Errorf("ValidateAll() invalid value = %v", err)
+	}
+
+	_, err = schema.ValidateAll(testParams(map[string]string{"username": "down"}))
+	var re *RuleError
+	if !errors.As(err, &re) || re.Key != "username" || !errors.Is(err, errDBUnavailable) {
+		t.Errorf("ValidateAll() rule error = %v", err)
+	}
+
+	_, key, err := schema.Validate(testParams(map[string]string{"username": "down"}))
+	if key != "username" || !errors.As(err, &re) {
+		t.Errorf("Validate() rule error = %s %v", key, err)
+	}
+}
+
+func TestContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ValidationAll(testParams(map[string]string{"username": "bool"}), contextRules(), WithContext(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ValidationAll() canceled = %v", err)
+	}
+}
+
+// 规则通过 RuleContext 获取 context，执行错误通过 Abort 返回
+func TestContextRule(t *testing.T) {
+	type ctxKey struct{}
+	restoreRule(t, "test_ctx")
+	if err := RegisterRule("test_ctx", func(c *RuleContext) error {
+		if c.Context().Value(ctxKey{}) == nil {
+			return c.Abort(errDBUnavailable)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	rules := []ValidationItem{{Key: "v", Rules: []ValidationRule{{Rule: "test_ctx"}}}}
+	ctx := context.WithValue(context.Background(), ctxKey{}, 1)
+	if _, err := ValidationAll(testParams(map[string]string{}), rules, WithContext(ctx)); err != nil {
+		t.Errorf("ValidationAll() failed. %v", err)
+	}
+	var re *RuleError
+	if _, err := ValidationAll(testParams(map[string]string{}), rules); !errors.As(err, &re) {
+		t.Errorf("ValidationAll() rule error = %v", err)
+	}
+}
+
+// 检查默认值时不执行需要访问外部资源的规则
+func TestContextDefault(t *testing.T) {
+	called := false
+	_, err := Compile([]ValidationItem{{Key: "username", Default: "guest", Rules: []ValidationRule{
+		{Rule: "func", Data: ValidationContextFuncRule{Func: func(ctx context.Context, val string) error {
+			called = true
+			return nil
+		}}},
+	}}})
+	if err != nil || called {
+		t.Errorf("Compile() = %v, called %v", err, called)
+	}
+}
@@ -0,0 +1,325 @@
+package validator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/18 10:10
+ * @Desc: 日期时间规则，按 Config 的时区解析，参数绑定到 time.Time 字段时使用验证项的日期格式
+ *
+ * {Rule: "date"}                                 2006-01-02 格式的日期
+ * {Rule: "date", Data: "2006/01/02"}             指定格式的日期
+ * {Rule: "datetime"}                             2006-01-02 15:04:05 格式的时间
+ * {Rule: "rfc3339"}                              RFC3339 格式的时间
+ * {Rule: "unix"}                                 秒级时间戳
+ * {Rule: "unix_ms"}                              毫秒级时间戳
+ * {Rule: "before", Data: "now"}                  早于当前时间
+ * {Rule: "after", Data: "2021-01-01"}            晚于指定时间
+ * {Rule: "before_or_equal", Data: "endAt"}       不晚于同级参数 endAt
+ * {Rule: "after_or_equal", Data: "startAt"}      不早于同级参数 startAt
+ * {Rule: "within", Data: []string{"-90d", "0d"}} 在 90 天前至今天之间
+ *
+ * before、after 等规则的参数为 now、日期字面量（2006-01-02、2006-01-02 15:04:05 或 RFC3339 格式）或同级参数键，
+ * 参数值及同级参数值按验证项中的日期格式规则解析，没有日期格式规则时按日期字面量的格式解析，同级参数有值但无法解析时验证失败
+ * within 的参数为相对当前时间的偏移，d 表示天，按自然日计算，其他单位与 time.ParseDuration 相同，如 -2h、30m
+ */
+
+const (
+	ValidateValDate          = "%s 必须是 %s 格式的时间"
+	ValidateValUnix          = "%s 必须是时间戳"
+	ValidateValBefore        = "%s 必须早于 %s"
+	ValidateValAfter         = "%s 必须晚于 %s"
+	ValidateValBeforeOrEqual = "%s 不能晚于 %s"
+	ValidateValAfterOrEqual  = "%s 不能早于 %s"
+	ValidateValWithin        = "%s 必须在 %s - %s 之间"
+	ValidateValTimeRef       = "%s 对应的 %s 不是合法的时间"
+	ConfigOffsetInvalid      = "时间偏移 %q 不合法"
+	TimestampOutOfRange      = "时间戳 %d 超出范围"
+)
+
+const (
+	MsgDate          = "date"
+	MsgUnix          = "unix"
+	MsgBefore        = "before"
+	MsgAfter         = "after"
+	MsgBeforeOrEqual = "before_or_equal"
+	MsgAfterOrEqual  = "after_or_equal"
+	MsgWithin        = "within"
+	MsgTimeRef       = "time_ref.invalid"
+)
+
+// 日期时间参数为当前时间
+const DateNow = "now"
+
+const (
+	DefaultDatetime = "2006-01-02 15:04:05"
+)
+
+// 时间戳支持的范围（秒），0001-01-01 00:00:00 至 9999-12-31 23:59:59 UTC
+const (
+	minUnixSecond = -62135596800
+	maxUnixSecond = 253402300799
+)
+
+// 日期时间格式，layout 为空时为时间戳
+type timeFormat struct {
+	layout string
+	unit   time.Duration // 时间戳单位
+}
+
+// 日期格式规则
+var timeFormats = map[string]timeFormat{
+	"date":     {layout: DefaultData},
+	"datetime": {layout: DefaultDatetime},
+	"rfc3339":  {layout: time.RFC3339},
+	"unix":     {unit: time.Second},
+	"unix_ms":  {unit: time.Millisecond},
+}
+
+// 比较规则及比较结果的要求
+var timeBounds = map[string]struct {
+	key    string
+	format string
+	accept func(n int) bool
+}{
+	"before":          {MsgBefore, ValidateValBefore, func(n int) bool { return n < 0 }},
+	"after":           {MsgAfter, ValidateValAfter, func(n int) bool { return n > 0 }},
+	"before_or_equal": {MsgBeforeOrEqual, ValidateValBeforeOrEqual, func(n int) bool { return n <= 0 }},
+	"after_or_equal":  {MsgAfterOrEqual, ValidateValAfterOrEqual, func(n int) bool { return n >= 0 }},
+}
+
+func init() {
+	for name := range timeFormats {
+		ruleRegistry[name] = &ruleEntry{compile: compileTimeFormat(name)}
+	}
+	for name := range timeBounds {
+		ruleRegistry[name] = &ruleEntry{compile: compileTimeBound(name)}
+	}
+	ruleRegistry["within"] = &ruleEntry{compile: compileWithin}
+}
+
+func (f timeFormat) parse(val string, loc *time.Location) (time.Time, error) {
+	if f.layout != "" {
+		return time.ParseInLocation(f.layout, val, loc)
+	}
+	n, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	sec, nsec := n, int64(0)
+	if f.unit == time.Millisecond {
+		sec, nsec = n/1000, n%1000*int64(time.Millisecond)
+	}
+	if sec < minUnixSecond || sec > maxUnixSecond {
+		return time.Time{}, fmt.Errorf(TimestampOutOfRange, n)
+	}
+	return time.Unix(sec, nsec).In(loc), nil
+}
+
+// 错误信息中显示时间的格式，时间戳显示为 DefaultDatetime 格式
+func (f timeFormat) display(t time.Time) string {
+	if f.layout == "" {
+		return t.Format(DefaultDatetime)
+	}
+	return t.Format(f.layout)
+}
+
+// 验证项的日期格式规则，没有时返回 DefaultData 格式及 false
+func itemTimeFormat(item *ValidationItem) (timeFormat, bool) {
+	for _, v := range item.Rules {
+		f, ok := timeFormats[v.Rule]
+		if !ok {
+			continue
+		}
+		if layout, ok := v.Data.(string); ok && v.Rule == "date" && layout != "" {
+			f.layout = layout
+		}
+		return f, true
+	}
+	return timeFormats["date"], false
+}
+
+// 按验证项的日期格式解析参数值，没有日期格式规则时按日期字面量的格式解析
+func parseItemTime(item *ValidationItem, val string, loc *time.Location) (time.Time, bool) {
+	if f, ok := itemTimeFormat(item); ok {
+		t, err := f.parse(val, loc)
+		return t, err == nil
+	}
+	return parseCompareDate(val, loc)
+}
+
+// 按验证项的日期格式解析参数值，用于使用 ValidateAll 等方法得到的参数
+func (s *Schema) Time(key, val string, opts ...Option) (time.Time, error) {
+	f := timeFormats["date"]
+	if item := s.item(key); item != nil {
+		f, _ = itemTimeFormat(&item.item)
+	}
+	return f.parse(val, newOptions(opts).config.Loc())
+}
+
+func compileTimeFormat(name string) ruleCompiler {
+	return func(data interface{}) (RuleFunc, error) {
+		f := timeFormats[name]
+		if name == "date" && data != nil {
+			layout, ok := data.(string)
+			if !ok {
+				return nil, fmt.Errorf(ConfigDataTypeNotAllow, data)
+			}
+			if layout != "" {
+				f.layout = layout
+			}
+		}
+
+		return func(c *RuleContext) error {
+			if c.Value == "" {
+				return nil
+			}
+			if _, err := f.parse(c.Value, c.Config().Loc()); err != nil {
+				return timeFormatError(c, f)
+			}
+			return nil
+		}, nil
+	}
+}
+
+// 参数值不符合时间格式的错误
+func timeFormatError(c *RuleContext, f timeFormat) error {
+	if f.layout == "" {
+		return newFieldError(c.Item, c.Index, c.Value, MsgUnix, ValidateValUnix)
+	}
+	return newFieldError(c.Item, c.Index, c.Value, MsgDate, ValidateValDate, f.layout)
+}
+
+func compileTimeBound(name string) ruleCompiler {
+	return func(data interface{}) (RuleFunc, error) {
+		ref, ok := data.(string)
+		if !ok {
+			return nil, fmt.Errorf(ConfigDataTypeNotAllow, data)
+		}
+		if ref == "" {
+			return nil, fmt.Errorf(ConfigDataEmpty)
+		}
+		_, literal := parseCompareDate(ref, time.UTC)
+		bound := timeBounds[name]
+
+		return func(c *RuleContext) error {
+			if c.Value == "" {
+				return nil
+			}
+			loc := c.Config().Loc()
+			f, _ := itemTimeFormat(c.Item)
+
+			var other time.Time
+			var display string
+			switch {
+			case ref == DateNow:
+				other = c.Config().Now()
+				display = f.display(other)
+			case literal:
+				other, _ = parseCompareDate(ref, loc)
+				display = ref
+			default:
+				otherVal := c.Param(ref)
+				if otherVal == "" {
+					return nil
+				}
+				display = c.FieldName(ref)
+				var ok bool
+				if other, ok = parseItemTime(c.Item, otherVal, loc); !ok {
+					return newFieldError(c.Item, c.Index, c.Value, MsgTimeRef, ValidateValTimeRef, display)
+				}
+			}
+
+			t, ok := parseItemTime(c.Item, c.Value, loc)
+			if ok && bound.accept(compareTime(t, other)) {
+				return nil
+			}
+			return newFieldError(c.Item, c.Index, c.Value, bound.key, bound.format, display)
+		}, nil
+	}
+}
+
+// 相对当前时间的偏移
+type timeOffset struct {
+	days int           // 天数，按自然日计算
+	dur  time.Duration // 其他单位的偏移
+	day  bool          // 是否以天为单位
+}
+
+func parseTimeOffset(s string) (timeOffset, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "d") {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return timeOffset{}, fmt.Errorf(ConfigOffsetInvalid, s)
+		}
+		return timeOffset{days: n, day: true}, nil
+	}
+	dur, err := time.ParseDuration(s)
+	if err != nil {
+		return timeOffset{}, fmt.Errorf(ConfigOffsetInvalid, s)
+	}
+	return timeOffset{dur: dur}, nil
+}
+
+// 偏移后的时间，以天为单位时下限为当天开始，上限为当天结束
+func (o timeOffset) from(now time.Time, upper bool) time.Time {
+	if !o.day {
+		return now.Add(o.dur)
+	}
+	y, m, d := now.Date()
+	start := time.Date(y, m, d+o.days, 0, 0, 0, 0, now.Location())
+	if upper {
+		return start.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return start
+}
+
+func compileWithin(data interface{}) (RuleFunc, error) {
+	list, ok := data.([]string)
+	if !ok {
+		return nil, fmt.Errorf(ConfigDataTypeNotAllow, data)
+	}
+	if len(list) != 2 {
+		return nil, fmt.Errorf(ConfigDataLength, 2)
+	}
+	var offsets [2]timeOffset
+	for i, v := range list {
+		o, err := parseTimeOffset(v)
+		if err != nil {
+			return nil, err
+		}
+		offsets[i] = o
+	}
+
+	return func(c *RuleContext) error {
+		if c.Value == "" {
+			return nil
+		}
+		now := c.Config().Now()
+		min, max := offsets[0].from(now, false), offsets[1].from(now, true)
+
+		t, ok := parseItemTime(c.Item, c.Value, c.Config().Loc())
+		if ok && !t.Before(min) && !t.After(max) {
+			return nil
+		}
+		f, _ := itemTimeFormat(c.Item)
+		return newFieldError(c.Item, c.Index, c.Value, MsgWithin, ValidateValWithin, f.display(min), f.display(max))
+	}, nil
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"testing"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/18 16:20
+ * @Desc:
+ */
+
+func TestDateRules(t *testing.T) {
+	tests := []struct {
+		rules  []ValidationRule
+		params map[string]string
+		expect bool
+	}{
+		{[]ValidationRule{{Rule: "date"}}, map[string]string{"v": "2020-02-29"}, true},
+		{[]ValidationRule{{Rule: "date"}}, map[string]string{"v": "2021-02-29"}, false},
+		{[]ValidationRule{{Rule: "date", Data: "2006/01/02"}}, map[string]string{"v": "2020/02/29"}, true},
+		{[]ValidationRule{{Rule: "datetime"}}, map[string]string{"v": "2020-02-29 23:59:59"}, true},
+		{[]ValidationRule{{Rule: "datetime"}}, map[string]string{"v": "2020-02-29"}, false},
+		{[]ValidationRule{{Rule: "rfc3339"}}, map[string]string{"v": "2020-02-29T23:59:59Z"}, true},
+		{[]ValidationRule{{Rule: "unix"}}, map[string]string{"v": "1609431000"}, true},
+		{[]ValidationRule{{Rule: "unix_ms"}}, map[string]string{"v": "16094310001a"}, false},
+
+		{[]ValidationRule{{Rule: "before", Data: "now"}}, map[string]string{"v": "2020-12-31"}, true},
+		{[]ValidationRule{{Rule: "datetime"}, {Rule: "before", Data: "now"}}, map[string]string{"v": "2021-01-01 00:40:00"}, false},
+		{[]ValidationRule{{Rule: "unix"}, {Rule: "before", Data: "now"}}, map[string]string{"v": "1609431000"}, true},
+		{[]ValidationRule{{Rule: "unix"}, {Rule: "before", Data: "now"}}, map[string]string{"v": "99999999999999"}, false},
+		{[]ValidationRule{{Rule: "unix"}}, map[string]string{"v": "-99999999999999"}, false},
+		{[]ValidationRule{{Rule: "unix_ms"}, {Rule: "before", Data: "now"}}, map[string]string{"v": "1609431000000"}, true},
+		{[]ValidationRule{{Rule: "unix_ms"}, {Rule: "after", Data: "2021-01-01"}}, map[string]string{"v": "99999999999999"}, true},
+		{[]ValidationRule{{Rule: "unix_ms"}}, map[string]string{"v": "9223372036854775807"}, false},
+		{[]ValidationRule{{Rule: "after", Data: "2020-01-01"}}, map[string]string{"v": "2020-01-01"}, false},
+		{[]ValidationRule{{Rule: "after_or_equal", Data: "2020-01-01"}}, map[string]string{"v": "2020-01-01"}, true},
+		{[]ValidationRule{{Rule: "before_or_equal", Data: "endAt"}}, map[string]string{"v": "2020-01-02", "endAt": "2020-01-01"}, false},
+		{[]ValidationRule{{Rule: "before_or_equal", Data: "endAt"}}, map[string]string{"v": "2020-01-01", "endAt": "2020-01-01"}, true},
+		{[]ValidationRule{{Rule: "before", Data: "endAt"}}, map[string]string{"v": "2020-01-01"}, true},
+		{[]ValidationRule{{Rule: "before", Data: "now"}}, map[string]string{"v": "yesterday"}, false},
+
+		{[]ValidationRule{{Rule: "within", Data: []string{"-90d", "0d"}}}, map[string]string{"v": "2020-10-03"}, true},
+		{[]ValidationRule{{Rule: "within", Data: []string{"-90d", "0d"}}}, map[string]string{"v": "2020-10-02"}, false},
+		{[]ValidationRule{{Rule: "within", Data: []string{"-90d", "0d"}}}, map[string]string{"v": "2021-01-01"}, true},
+		{[]ValidationRule{{Rule: "within", Data: []string{"-90d", "0d"}}}, map[string]string{"v": "2021-01-02"}, false},
+		{[]ValidationRule{{Rule: "datetime"}, {Rule: "within", Data: []string{"-1h", "0s"}}}, map[string]string{"v": "2021-01-01 00:40:00"}, false},
+		{[]ValidationRule{{Rule: "datetime"}, {Rule: "within", Data: []string{"-1h", "0s"}}}, map[string]string{"v": "2020-12-31 23:40:00"}, true},
+	}
+
+	for _, test := range tests {
+		rules := []ValidationItem{{Key: "v", Name: "v", Rules: test.rules}}
+		_, err := ValidationAll(testParams(test.params), rules, WithConfig(frozenConfig()))
+		if (err == nil) != test.expect {
+			t.Errorf("ValidationAll(%v, %v) = %v", test.rules, test.params, err)
+		}
+	}
+}
+
+func TestDateRulesConfig(t *testing.T) {
+	tests := []ValidationRule{
+		{Rule: "date", Data: 1},
+		{Rule: "before"},
+		{Rule: "within", Data: []string{"-90d"}},
+		{Rule: "within", Data: []string{"-90x", "0d"}},
+	}
+	for _, rule := range tests {
+		if _, err := Compile([]ValidationItem{{Key: "v", Rules: []ValidationRule{rule}}}); err == nil {
+			t.Errorf("Compile(%v) should fail", rule)
+		}
+	}
+}
+
+func TestDateMessage(t *testing.T) {
+	rules := []ValidationItem{{Key: "startAt", Name: "开始时间", Rules: MustParseRules("datetime|within:-1h,0s")}}
+	_, _, err := Validation(testParams(map[string]string{"startAt": "2021-01-01 00:40:00"}), rules, WithConfig(frozenConfig()))
+	if err == nil || err.Error() != "开始时间 必须在 2020-12-31 23:30:00 - 2021-01-01 00:30:00 之间" {
+		t.Errorf("Validation() = %v", err)
+	}
+}
+
+// 同级参数有值但无法解析时验证失败
+func TestDateRefInvalid(t *testing.T) {
+	for _, rule := range []string{"before", "after", "before_or_equal", "after_or_equal"} {
+		rules := []ValidationItem{
+			{Key: "startAt", Name: "开始时间", Rules: []ValidationRule{{Rule: rule, Data: "endAt"}}},
+			{Key: "endAt", Name: "结束时间"},
+		}
+		_, _, err := Validation(testParams(map[string]string{"startAt": "2021-01-05", "endAt": "junk"}), rules)
+		if fe, ok := err.(*FieldError); !ok || fe.MsgKey != MsgTimeRef || fe.Error() != "开始时间 对应的 结束时间 不是合法的时间" {
+			t.Errorf("Validation(%s) = %v", rule, err)
+		}
+	}
+}
+
+func TestDateBind(t *testing.T) {
+	type form struct {
+		StartAt  time.Time  `form:"startAt" validate:"unix"`
+		Birthday *time.Time `form:"birthday" validate:"date=2006/01/02"`
+		EndAt    time.Time  `form:"endAt" validate:"datetime"`
+	}
+	var f form
+	params := testParams(map[string]string{"startAt": "1609431000", "birthday": "2010/01/02", "endAt": "2021-01-01 08:00:00"})
+	if err := Bind(params, &f, WithConfig(&Config{Location: time.UTC})); err != nil {
+		t.Fatalf("Bind() failed. %v", err)
+	}
+	if f.StartAt.Unix() != 1609431000 || f.Birthday.Day() != 2 || f.EndAt.Unix() != 1609488000 {
+		t.Errorf("Bind() = %+v", f)
+	}
+
+	schema := MustCompile([]ValidationItem{{Key: "startAt", Rules: []ValidationRule{{Rule: "unix_ms"}}}})
+	if tm, err := schema.Time("startAt", "1609431000000"); err != nil || tm.Unix() != 1609431000 {
+		t.Errorf("Schema.Time() = %v, %v", tm, err)
+	}
+}
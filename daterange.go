@@ -0,0 +1,206 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/22 10:30
+ * @Desc: 时间范围规则，检查开始、结束时间的先后、跨度及是否晚于当前时间
+ *
+ * {Rule: "date_range", Data: DateRangeRule{Start: "startAt", End: "endAt", MaxSpan: "31d", NotFuture: true}}
+ *
+ * 规则可设置在开始时间或结束时间的验证项上，NotFuture 检查设置了规则的验证项；
+ * 两者都设置时先后顺序及跨度只由结束时间检查，错误属于结束时间，只设置在开始时间上时错误属于开始时间
+ * 设置了规则的验证项或另一个时间有值但无法解析时验证失败
+ * 开始、结束时间按 Layout 解析，Layout 为空时按各自验证项的日期格式规则解析
+ * 标签及规则字符串中的参数为 开始时间参数键 结束时间参数键 [max=31d] [min=1d] [layout=unix] [not_future]
+ */
+
+const (
+	ValidateValRangeOrder   = "%s 不能早于 %s"
+	ValidateValRangeBefore  = "%s 不能晚于 %s"
+	ValidateValRangeInvalid = "%s 对应的 %s 不是合法的时间"
+	ValidateValRangeMaxSpan = "%s 与 %s 相差不能超过 %s"
+	ValidateValRangeMinSpan = "%s 与 %s 相差不能少于 %s"
+	ValidateValRangeFuture  = "%s 不能晚于当前时间"
+	ConfigRangeOption       = "不支持的选项 %q"
+)
+
+const (
+	MsgRangeOrder   = "date_range.order"
+	MsgRangeBefore  = "date_range.before"
+	MsgRangeInvalid = "date_range.invalid"
+	MsgRangeMaxSpan = "date_range.max"
+	MsgRangeMinSpan = "date_range.min"
+	MsgRangeFuture  = "date_range.future"
+)
+
+// 时间范围规则参数
+type DateRangeRule struct {
+	Start     string // 开始时间参数键
+	End       string // 结束时间参数键
+	Layout    string // 时间格式，可以是 date、datetime、rfc3339、unix、unix_ms 或 time.Parse 的格式
+	MinSpan   string // 最小跨度，如 1d、2h，为空时不限制
+	MaxSpan   string // 最大跨度，如 31d，为空时不限制
+	NotFuture bool   // 开始、结束时间都不能晚于当前时间
+}
+
+func init() {
+	ruleRegistry["date_range"] = &ruleEntry{compile: compileDateRange}
+}
+
+// 将标签或规则字符串中的参数转换为 DateRangeRule
+func parseDateRangeFields(fields []string) (DateRangeRule, error) {
+	if len(fields) < 2 {
+		return DateRangeRule{}, fmt.Errorf(ConfigDataMinLength, 2)
+	}
+	rule := DateRangeRule{Start: fields[0], End: fields[1]}
+	for _, v := range fields[2:] {
+		name, val := v, ""
+		if i := strings.Index(v, "="); i >= 0 {
+			name, val = v[:i], v[i+1:]
+		}
+		switch name {
+		case "max":
+			rule.MaxSpan = val
+		case "min":
+			rule.MinSpan = val
+		case "layout":
+			rule.Layout = val
+		case "not_future":
+			rule.NotFuture = true
+		default:
+			return DateRangeRule{}, fmt.Errorf(ConfigRangeOption, v)
+		}
+	}
+	return rule, nil
+}
+
+// 跨度，以天为单位时按自然日相加
+type timeSpan struct {
+	text   string
+	offset timeOffset
+}
+
+func parseTimeSpan(s string) (*timeSpan, error) {
+	if s == "" {
+		return nil, nil
+	}
+	o, err := parseTimeOffset(s)
+	if err != nil {
+		return nil, err
+	}
+	return &timeSpan{text: s, offset: o}, nil
+}
+
+func (s *timeSpan) add(t time.Time) time.Time {
+	if s.offset.day {
+		return t.AddDate(0, 0, s.offset.days)
+	}
+	return t.Add(s.offset.dur)
+}
+
+func compileDateRange(data interface{}) (RuleFunc, error) {
+	rule, ok := data.(DateRangeRule)
+	if !ok {
+		return nil, fmt.Errorf(ConfigDataTypeNotAllow, data)
+	}
+	if rule.Start == "" || rule.End == "" {
+		return nil, fmt.Errorf(ConfigDataEmpty)
+	}
+	minSpan, err := parseTimeSpan(rule.MinSpan)
+	if err != nil {
+		return nil, err
+	}
+	maxSpan, err := parseTimeSpan(rule.MaxSpan)
+	if err != nil {
+		return nil, err
+	}
+
+	layout, ok := timeFormats[rule.Layout]
+	if !ok {
+		layout = timeFormat{layout: rule.Layout}
+	}
+	// 按规则的时间格式或验证项的日期格式规则解析
+	parse := func(c *RuleContext, item *ValidationItem, val string) (time.Time, bool) {
+		if rule.Layout == "" {
+			return parseItemTime(item, val, c.Config().Loc())
+		}
+		t, err := layout.parse(val, c.Config().Loc())
+		return t, err == nil
+	}
+
+	return func(c *RuleContext) error {
+		if c.Value == "" {
+			return nil
+		}
+		t, ok := parse(c, c.Item, c.Value)
+		if !ok {
+			if rule.Layout == "" {
+				f, _ := itemTimeFormat(c.Item)
+				return timeFormatError(c, f)
+			}
+			return timeFormatError(c, layout)
+		}
+		if rule.NotFuture && t.After(c.Config().Now()) {
+			return newFieldError(c.Item, c.Index, c.Value, MsgRangeFuture, ValidateValRangeFuture)
+		}
+		// 开始、结束时间都设置了规则时由结束时间检查
+		isEnd := c.isKey(rule.End)
+		other := rule.Start
+		if !isEnd {
+			if !c.isKey(rule.Start) || hasDateRange(c.sibling(rule.End), rule) {
+				return nil
+			}
+			other = rule.End
+		}
+
+		otherVal := c.Param(other)
+		if otherVal == "" {
+			return nil
+		}
+		otherItem := c.Item
+		if sibling := c.sibling(other); sibling != nil {
+			otherItem = &sibling.item
+		}
+		otherName := c.FieldName(other)
+		ot, ok := parse(c, otherItem, otherVal)
+		if !ok {
+			return newFieldError(c.Item, c.Index, c.Value, MsgRangeInvalid, ValidateValRangeInvalid, otherName)
+		}
+
+		start, end := ot, t
+		if !isEnd {
+			start, end = t, ot
+		}
+		switch {
+		case end.Before(start) && isEnd:
+			return newFieldError(c.Item, c.Index, c.Value, MsgRangeOrder, ValidateValRangeOrder, otherName)
+		case end.Before(start):
+			return newFieldError(c.Item, c.Index, c.Value, MsgRangeBefore, ValidateValRangeBefore, otherName)
+		case maxSpan != nil && end.After(maxSpan.add(start)):
+			return newFieldError(c.Item, c.Index, c.Value, MsgRangeMaxSpan, ValidateValRangeMaxSpan, otherName, maxSpan.text)
+		case minSpan != nil && end.Before(minSpan.add(start)):
+			return newFieldError(c.Item, c.Index, c.Value, MsgRangeMinSpan, ValidateValRangeMinSpan, otherName, minSpan.text)
+		}
+		return nil
+	}, nil
+}
+
+// 验证项是否设置了开始、结束时间相同的时间范围规则
+func hasDateRange(v *compiledItem, rule DateRangeRule) bool {
+	if v == nil {
+		return false
+	}
+	for _, r := range v.item.Rules {
+		if d, ok := r.Data.(DateRangeRule); ok && r.Rule == "date_range" && d.Start == rule.Start && d.End == rule.End {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package validator
+
+import "testing"
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/22 15:10
+ * @Desc:
+ */
+
+func dateRangeItems(rule DateRangeRule, startRules ...ValidationRule) []ValidationItem {
+	dr := ValidationRule{Rule: "date_range", Data: rule}
+	return []ValidationItem{
+		{Key: "startAt", Name: "开始时间", Rules: append(startRules, dr)},
+		{Key: "endAt", Name: "结束时间", Rules: append(append([]ValidationRule(nil), startRules...), dr)},
+	}
+}
+
+func TestDateRange(t *testing.T) {
+	rule := DateRangeRule{Start: "startAt", End: "endAt", MinSpan: "1d", MaxSpan: "31d", NotFuture: true}
+	tests := []struct {
+		params map[string]string
+		key    string // 出错的参数键，为空时验证通过
+		msg    string
+	}{
+		{map[string]string{"startAt": "2020-12-01", "endAt": "2020-12-31"}, "", ""},
+		{map[string]string{"startAt": "2020-12-01", "endAt": "2021-01-01"}, "", ""},
+		{map[string]string{"startAt": "2020-12-01"}, "", ""},
+		{map[string]string{"startAt": "2020-12-31", "endAt": "2020-12-01"}, "endAt", "结束时间 不能早于 开始时间"},
+		{map[string]string{"startAt": "2020-11-01", "endAt": "2020-12-31"}, "endAt", "结束时间 与 开始时间 相差不能超过 31d"},
+		{map[string]string{"startAt": "2020-12-01", "endAt": "2020-12-01"}, "endAt", "结束时间 与 开始时间 相差不能少于 1d"},
+		{map[string]string{"startAt": "2021-01-02", "endAt": "2021-01-05"}, "startAt", "开始时间 不能晚于当前时间"},
+	}
+
+	schema := MustCompile(dateRangeItems(rule, ValidationRule{Rule: "date"}))
+	for _, test := range tests {
+		_, key, err := schema.Validate(testParams(test.params), WithConfig(frozenConfig()))
+		if key != test.key || (err != nil && err.Error() != test.msg) {
+			t.Errorf("Validate(%v) = %s %v", test.params, key, err)
+		}
+	}
+}
+
+func TestDateRangeUnix(t *testing.T) {
+	rule := DateRangeRule{Start: "startAt", End: "endAt", Layout: "unix", MaxSpan: "2h"}
+	schema := MustCompile(dateRangeItems(rule))
+	if _, err := schema.ValidateAll(testParams(map[string]string{"startAt": "1609430000", "endAt": "1609437200"})); err != nil {
+		t.Errorf("ValidateAll() failed. %v", err)
+	}
+	if _, err := schema.ValidateAll(testParams(map[string]string{"startAt": "1609430000", "endAt": "1609437201"})); err == nil {
+		t.Error("ValidateAll() span should fail")
+	}
+}
+
+func TestDateRangeTag(t *testing.T) {
+	type form struct {
+		StartAt string `form:"startAt" validate:"datetime,date_range=startAt endAt max=7d not_future"`
+		EndAt   string `form:"endAt" validate:"datetime,date_range=startAt endAt max=7d not_future"`
+	}
+	params := testParams(map[string]string{"startAt": "2020-12-20 00:00:00", "endAt": "2020-12-31 00:00:00"})
+	var f form
+	err := Bind(params, &f, WithConfig(frozenConfig()))
+	if fe, ok := err.(*FieldError); !ok || fe.Key != "endAt" || fe.MsgKey != MsgRangeMaxSpan {
+		t.Errorf("Bind() = %v", err)
+	}
+
+	if _, err := ParseRules("date_range:startAt,endAt,foo"); err == nil {
+		t.Error("ParseRules() unknown option should fail")
+	}
+}
+
+// 规则只设置在开始时间上时同样检查先后顺序及跨度，另一个时间无法解析时验证失败
+func TestDateRangeOneSide(t *testing.T) {
+	dr := ValidationRule{Rule: "date_range", Data: DateRangeRule{Start: "startAt", End: "endAt", MaxSpan: "31d"}}
+	startOnly := MustCompile([]ValidationItem{
+		{Key: "startAt", Name: "开始时间", Rules: []ValidationRule{{Rule: "date"}, dr}},
+		{Key: "endAt", Name: "结束时间", Rules: []ValidationRule{{Rule: "date"}}},
+	})
+	endOnly := MustCompile([]ValidationItem{
+		{Key: "startAt", Name: "开始时间"},
+		{Key: "endAt", Name: "结束时间", Rules: []ValidationRule{{Rule: "date"}, dr}},
+	})
+	tests := []struct {
+		schema *Schema
+		params map[string]string
+		key    string
+		msg    string
+	}{
+		{startOnly, map[string]string{"startAt": "2020-12-01", "endAt": "2020-12-31"}, "", ""},
+		{startOnly, map[string]string{"startAt": "2020-12-31", "endAt": "2020-12-01"}, "startAt", "开始时间 不能晚于 结束时间"},
+		{startOnly, map[string]string{"startAt": "2020-11-01", "endAt": "2020-12-31"}, "startAt", "开始时间 与 结束时间 相差不能超过 31d"},
+		{endOnly, map[string]string{"startAt": "2020/12/01", "endAt": "2020-12-31"}, "endAt", "结束时间 对应的 开始时间 不是合法的时间"},
+	}
+
+	for _, test := range tests {
+		_, key, err := test.schema.Validate(testParams(test.params))
+		if key != test.key || (err != nil && err.Error() != test.msg) {
+			t.Errorf("Validate(%v) = %s %v", test.params, key, err)
+		}
+	}
+}
+
+// 设置了规则的验证项无法解析时验证失败，不依赖日期格式规则
+func TestDateRangeInvalidValue(t *testing.T) {
+	tests := []struct {
+		rule   DateRangeRule
+		params map[string]string
+		msg    string
+	}{
+		{DateRangeRule{Start: "startAt", End: "endAt"}, map[string]string{"startAt": "2021-01-01", "endAt": "garbage"}, "结束时间 必须是 2006-01-02 格式的时间"},
+		{DateRangeRule{Start: "startAt", End: "endAt"}, map[string]string{"endAt": "garbage"}, "结束时间 必须是 2006-01-02 格式的时间"},
+		{DateRangeRule{Start: "startAt", End: "endAt", Layout: "unix"}, map[string]string{"startAt": "1609430000", "endAt": "x"}, "结束时间 必须是时间戳"},
+	}
+
+	for _, test := range tests {
+		schema := MustCompile([]ValidationItem{
+			{Key: "startAt", Name: "开始时间"},
+			{Key: "endAt", Name: "结束时间", Rules: []ValidationRule{{Rule: "date_range", Data: test.rule}}},
+		})
+		data, key, err := schema.Validate(testParams(test.params))
+		if key != "endAt" || err == nil || err.Error() != test.msg {
+			t.Errorf("Validate(%v) = %v %s %v", test.params, data, key, err)
+		}
+	}
+}
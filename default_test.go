@@ -0,0 +1,131 @@
+package validator
+
+import "testing"
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/31 11:12
+ * @Desc:
+ */
+
+func TestDefault(t *testing.T) {
+	rules := []ValidationItem{
+		{Key: "pageNum", Name: "页号", Default: "1", Rules: []ValidationRule{{Rule: "required"}, {Rule: "min", Data: 1}}},
+		{Key: "pageSize", Name: "每页记录条数", Default: "20", Rules: []ValidationRule{{Rule: "between", Data: []int{1, 100}}}},
+		{Key: "sort", Name: "排序", Multiple: true, Default: "id,name", Rules: []ValidationRule{{Rule: "in", Data: []string{"id", "name"}}}},
+	}
+
+	data, _, err := Validation(testParams(map[string]string{"pageSize": "50"}), rules)
+	if err != nil {
+		t.Fatalf("Validation() failed. %v", err)
+	}
+	if data["pageNum"] != "1" || data["pageSize"] != "50" || data["sort"] != "id,name" {
+		t.Errorf("Validation() unexpected data: %v", data)
+	}
+}
+
+func TestDefaultChecked(t *testing.T) {
+	tests := []struct {
+		item   ValidationItem
+		expect bool
+	}{
+		{ValidationItem{Key: "pageSize", Default: "200", Rules: []ValidationRule{{Rule: "between", Data: []int{1, 100}}}}, false},
+		{ValidationItem{Key: "pageSize", Default: "a", Rules: []ValidationRule{{Rule: "integer"}}}, false},
+		{ValidationItem{Key: "sort", Multiple: true, Default: "id,age", Rules: []ValidationRule{{Rule: "in", Data: []string{"id"}}}}, false},
+		{ValidationItem{Key: "pageSize", Default: " 20 ", Rules: []ValidationRule{{Rule: "trim"}, {Rule: "integer"}}}, true},
+		// 依赖其他参数的规则不检查默认值
+		{ValidationItem{Key: "endAt", Default: "2021-01-01", Rules: []ValidationRule{{Rule: "eqfield", Data: "startAt"}}}, true},
+		// 依赖当前时间的规则不检查默认值，其他规则仍然检查
+		{ValidationItem{Key: "endAt", Default: "2999-01-01", Rules: MustParseRules("date|before:now")}, true},
+		{ValidationItem{Key: "endAt", Default: "2000-01-01", Rules: MustParseRules("date|within:-90d,0d")}, true},
+		{ValidationItem{Key: "birthday", Default: "2020-01-01", Rules: MustParseRules("age:18")}, true},
+		{ValidationItem{Key: "endAt", Default: "2999-13-01", Rules: MustParseRules("date|before:now")}, false},
+	}
+
+	for _, test := range tests {
+		_, err := Compile([]ValidationItem{test.item})
+		if (err == nil) != test.expect {
+			t.Errorf("Compile(%s default %q) = %v", test.item.Key, test.item.Default, err)
+		}
+	}
+}
+
+type testDefaultRequest struct {
+	PageNum  int    `form:"pageNum" validate:"min=1" default:"1"`
+	PageSize int    `form:"pageSize" validate:"between=1 100" default:"20"`
+	Sort     string `form:"sort" validate:"in=id name"`
+}
+
+// 结构体标签中的默认值
+func TestStructDefault(t *testing.T) {
+	items, err := StructItems(&testDefaultRequest{})
+	if err != nil {
+		t.Fatalf("StructItems() failed. %v", err)
+	}
+	if items[0].Default != "1" || items[1].Default != "20" || items[2].Default != "" {
+		t.Errorf("StructItems() unexpected defaults: %+v", items)
+	}
+
+	var req testDefaultRequest
+	if err := Bind(testParams(map[string]string{"pageNum": "3"}), &req); err != nil {
+		t.Fatalf("Bind() failed. %v", err)
+	}
+	if req.PageNum != 3 || req.PageSize != 20 || req.Sort != "" {
+		t.Errorf("Bind() unexpected result: %+v", req)
+	}
+
+	type invalid struct {
+		PageSize int `form:"pageSize" validate:"between=1 100" default:"200"`
+	}
+	if _, err := StructSchema(&invalid{}); err == nil {
+		t.Error("StructSchema() should check default value.")
+	}
+}
+
+// 自定义规则可能访问外部资源，编译时不使用默认值执行
+func TestDefaultCustomRuleNotCalled(t *testing.T) {
+	restoreRule(t, "testDefaultRemote")
+	restoreRule(t, "integer")
+	calls := 0
+	_ = RegisterRule("testDefaultRemote", func(c *RuleContext) error {
+		calls++
+		return nil
+	})
+	_ = OverrideRule("integer", func(c *RuleContext) error {
+		calls++
+		return nil
+	})
+
+	items := []ValidationItem{
+		{Key: "a", Default: "1", Rules: []ValidationRule{{Rule: "testDefaultRemote"}, {Rule: "between", Data: []int{5, 9}}}},
+		{Key: "b", Default: "1", Rules: []ValidationRule{{Rule: "integer"}}},
+	}
+	if _, err := Compile(items); err != nil {
+		t.Fatalf("Compile() failed. %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("Compile() called custom rules %d times", calls)
+	}
+}
+
+// 参数提交空值时不使用默认值
+func TestDefaultPresentEmpty(t *testing.T) {
+	schema := MustCompile([]ValidationItem{{Key: "name", Name: "名称", Default: "x"}})
+	tests := []struct {
+		src    Source
+		expect string
+	}{
+		{Values{"name": {""}}, ""},
+		{Values{}, "x"},
+		{LookupFunc(func(string) (string, bool) { return "", true }), ""},
+		{ParamsFunc(testParams(map[string]string{})), "x"},
+	}
+
+	for _, test := range tests {
+		data, _, err := schema.ValidateSource(test.src)
+		if err != nil || data["name"] != test.expect {
+			t.Errorf("ValidateSource(%v) = %q %v, want %q", test.src, data["name"], err, test.expect)
+		}
+	}
+}
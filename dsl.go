@@ -0,0 +1,126 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/10 10:25
+ * @Desc: 规则字符串，如 "required|integer|between:1,100|in:A,B,C"
+ *
+ * 规则之间以 | 分隔，规则名称与参数以 : 分隔，多个参数以 , 分隔，参数中不能含有 | 及 ,
+ * between、min、max 的参数：规则中含有 integer 时按整数比较，任一大小规则的参数含有小数点时全部按浮点数比较，
+ * 否则按字符串长度比较，同一规则字符串中的大小规则始终按同一种方式比较
+ * func、regexp 的参数为内置的自定义验证方法及正则名称，如 func:idCard、regexp:mobile
+ * 自定义规则的参数原样作为 string 类型的 Data，没有参数时 Data 为 nil，自定义规则须在解析前注册
+ */
+
+const (
+	SyntaxErrorFormat = "规则 %q 第 %d 个字符处的 %q 不合法：%v"
+	SyntaxRuleEmpty   = "规则不能为空"
+)
+
+// 规则字符串解析错误
+type SyntaxError struct {
+	Rules  string // 规则字符串
+	Offset int    // 出错规则在规则字符串中的字节偏移
+	Token  string // 出错的规则
+	Err    error  // 具体错误
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf(SyntaxErrorFormat, e.Rules, e.Offset+1, e.Token, e.Err)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// 解析规则字符串，规则及参数不合法时返回 *SyntaxError
+func ParseRules(s string) ([]ValidationRule, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, "|")
+	kind := dslSizeKind(parts)
+
+	rules := make([]ValidationRule, 0, len(parts))
+	offset := 0
+	for _, part := range parts {
+		token := strings.TrimSpace(part)
+		pos := offset + strings.Index(part, token)
+		offset += len(part) + 1
+
+		rule, err := parseRule(token, kind)
+		if err != nil {
+			return nil, &SyntaxError{Rules: s, Offset: pos, Token: token, Err: err}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// 解析规则字符串，出错时 panic，适用于包级变量初始化
+func MustParseRules(s string) []ValidationRule {
+	rules, err := ParseRules(s)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// between、min、max 参数的类型，规则中含有 integer 时按整数比较，
+// 任一大小规则的参数含有小数点时按浮点数比较，其他情况按字符串长度比较
+func dslSizeKind(parts []string) string {
+	kind := "string"
+	for _, part := range parts {
+		token := strings.TrimSpace(part)
+		name, param := token, ""
+		if i := strings.Index(token, ":"); i >= 0 {
+			name, param = strings.TrimSpace(token[:i]), token[i+1:]
+		}
+		switch name {
+		case "integer":
+			return "int"
+		case "between", "min", "max":
+			if strings.Contains(param, ".") {
+				kind = "float"
+			}
+		}
+	}
+	return kind
+}
+
+// 解析单条规则并检查规则参数
+func parseRule(token string, kind string) (ValidationRule, error) {
+	if token == "" {
+		return ValidationRule{}, fmt.Errorf(SyntaxRuleEmpty)
+	}
+
+	name, param := token, ""
+	hasParam := false
+	if i := strings.Index(token, ":"); i >= 0 {
+		name, param, hasParam = strings.TrimSpace(token[:i]), token[i+1:], true
+	}
+	var fields []string
+	for _, v := range strings.Split(param, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			fields = append(fields, v)
+		}
+	}
+
+	data, err := ruleData(name, param, fields, hasParam, kind, false)
+	if err != nil {
+		return ValidationRule{}, err
+	}
+
+	rule := ValidationRule{Rule: name, Data: data}
+	if _, _, err := compileRule(&ValidationItem{}, rule); err != nil {
+		return ValidationRule{}, err
+	}
+	return rule, nil
+}
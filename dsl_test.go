@@ -0,0 +1,87 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/10 15:40
+ * @Desc:
+ */
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		rules  string
+		expect []ValidationRule
+	}{
+		{"", nil},
+		{"required|integer|between:1,100|in:A,B,C", []ValidationRule{
+			{Rule: "required"}, {Rule: "integer"}, {Rule: "between", Data: []int{1, 100}}, {Rule: "in", Data: []string{"A", "B", "C"}},
+		}},
+		{"min:0.5 | max:99.9", []ValidationRule{{Rule: "min", Data: 0.5}, {Rule: "max", Data: 99.9}}},
+		{"min:0.5|max:10", []ValidationRule{{Rule: "min", Data: 0.5}, {Rule: "max", Data: 10.0}}},
+		{"between:1,10|max:9.5", []ValidationRule{{Rule: "between", Data: []float64{1, 10}}, {Rule: "max", Data: 9.5}}},
+		{"max:10|integer", []ValidationRule{{Rule: "max", Data: 10}, {Rule: "integer"}}},
+		{"required|between:2,20", []ValidationRule{{Rule: "required"}, {Rule: "between", Data: []string{"2", "20"}}}},
+		{"integer|min:1", []ValidationRule{{Rule: "integer"}, {Rule: "min", Data: 1}}},
+		{"arrayInArray:a,b|distinct", []ValidationRule{{Rule: "arrayInArray", Data: []interface{}{",", []string{"a", "b"}}}, {Rule: "distinct", Data: ","}}},
+		{"required_if:certType,ID|func:idCard", []ValidationRule{{Rule: "required_if", Data: []string{"certType", "ID"}}, {Rule: "func", Data: ValidationIdCardCodeData()}}},
+		{"trim|default:1|gtfield:startAt", []ValidationRule{{Rule: "trim"}, {Rule: "default", Data: "1"}, {Rule: "gtfield", Data: "startAt"}}},
+	}
+
+	for _, test := range tests {
+		rules, err := ParseRules(test.rules)
+		if err != nil {
+			t.Errorf("ParseRules(%q) failed. %v", test.rules, err)
+			continue
+		}
+		// func 规则的 Data 含有方法，只比较规则名称
+		if len(rules) > 1 && rules[1].Rule == "func" {
+			rules[1].Data, test.expect[1].Data = nil, nil
+		}
+		if !reflect.DeepEqual(rules, test.expect) {
+			t.Errorf("ParseRules(%q) = %v, expect %v", test.rules, rules, test.expect)
+		}
+	}
+}
+
+func TestParseRulesError(t *testing.T) {
+	tests := []struct {
+		rules  string
+		offset int
+		token  string
+	}{
+		{"required|integr|min:1", 9, "integr"},
+		{"required||integer", 9, ""},
+		{"integer|between:1,a", 8, "between:1,a"},
+		{"integer|between:10,1", 8, "between:10,1"},
+		{"required| min:1.5.5", 10, "min:1.5.5"},
+		{"in", 0, "in"},
+		{"func:unknown", 0, "func:unknown"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseRules(test.rules)
+		var se *SyntaxError
+		if !errors.As(err, &se) || se.Offset != test.offset || se.Token != test.token {
+			t.Errorf("ParseRules(%q) = %v", test.rules, err)
+		}
+	}
+}
+
+func TestParseRulesValidate(t *testing.T) {
+	rules := []ValidationItem{
+		{Key: "pageSize", Name: "每页记录条数", Rules: MustParseRules("required|integer|between:1,100")},
+		{Key: "status", Name: "状态", Rules: MustParseRules("in:A,B,C")},
+	}
+	if _, err := ValidationAll(testParams(map[string]string{"pageSize": "20", "status": "A"}), rules); err != nil {
+		t.Errorf("ValidationAll() failed. %v", err)
+	}
+	if _, err := ValidationAll(testParams(map[string]string{"pageSize": "200", "status": "D"}), rules); len(err.(ValidationErrors)) != 2 {
+		t.Errorf("ValidationAll() = %v", err)
+	}
+}
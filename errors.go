@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/12 10:21
+ * @Desc: 验证错误类型
+ */
+
+// 字段验证错误，记录未通过的参数与规则
+type FieldError struct {
+	Key     string        // 参数键
+	Name    string        // 参数名称
+	Rule    string        // 未通过的规则名称
+	Param   interface{}   // 规则参数，即 ValidationRule.Data
+	Value   string        // 提交的值
+	MsgKey  string        // 错误信息的翻译键
+	Format  string        // 默认错误信息格式，第一个占位符为参数名称
+	Args    []interface{} // 错误信息中参数名称之后的参数
+	Message string        // 翻译后的错误信息，为空时使用 Format
+	Err     error         // 规则返回的原始错误
+}
+
+func (e *FieldError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Format == "" && e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf(e.Format, append([]interface{}{e.Name}, e.Args...)...)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// 构造字段验证错误
+func newFieldError(rule *ValidationItem, index int, val string, key, format string, args ...interface{}) *FieldError {
+	return &FieldError{
+		Key:    rule.Key,
+		Name:   rule.Name,
+		Rule:   rule.Rules[index].Rule,
+		Param:  rule.Rules[index].Data,
+		Value:  val,
+		MsgKey: key,
+		Format: format,
+		Args:   args,
+	}
+}
+
+// 将规则返回的错误统一转换为 FieldError
+func toFieldError(rule *ValidationItem, index int, val string, err error) *FieldError {
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return fe
+	}
+	fe = newFieldError(rule, index, val, "", "")
+	fe.Err = err
+	return fe
+}
+
+// 单个参数的全部验证错误
+type ItemErrors struct {
+	Key      string           // 参数键
+	Errors   []error          // 该参数所有未通过的规则错误
+	Children ValidationErrors // 对象或数组参数的子项错误
+}
+
+// 验证错误集合，按验证项的顺序排列
+type ValidationErrors []ItemErrors
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, item := range e.Flatten() {
+		for _, err := range item.Errors {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// 展开子项错误，按顺序返回所有含有规则错误的参数，不含 Children
+func (e ValidationErrors) Flatten() ValidationErrors {
+	var flat ValidationErrors
+	for _, item := range e {
+		if len(item.Errors) > 0 {
+			flat = append(flat, ItemErrors{Key: item.Key, Errors: item.Errors})
+		}
+		flat = append(flat, item.Children.Flatten()...)
+	}
+	return flat
+}
+
+// 获取某个参数的全部错误，包括子项中的参数
+func (e ValidationErrors) Get(key string) []error {
+	for _, item := range e.Flatten() {
+		if item.Key == key {
+			return item.Errors
+		}
+	}
+	return nil
+}
+
+// 参数键 => 第一条错误信息，便于直接输出给前端，子项参数键为完整路径
+func (e ValidationErrors) Fields() map[string]string {
+	flat := e.Flatten()
+	fields := make(map[string]string, len(flat))
+	for _, item := range flat {
+		fields[item.Key] = item.Errors[0].Error()
+	}
+	return fields
+}
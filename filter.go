@@ -0,0 +1,126 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/27 10:30
+ * @Desc: 过滤器，修改参数值后交给后续规则，处理后的值保存到验证结果中
+ *
+ * {Rule: "trim"}                去除首尾空白
+ * {Rule: "lower"}               转为小写
+ * {Rule: "upper"}               转为大写
+ * {Rule: "strip_tags"}          去除 HTML 标签
+ * {Rule: "full_to_half_width"}  全角字符转为半角
+ * {Rule: "collapse_spaces"}     连续空白合并为一个空格
+ * {Rule: "default", Data: "1"}  参数为空时使用默认值
+ *
+ * 自定义规则修改 RuleContext.Value 同样可以作为过滤器使用
+ * 其他规则通过 RuleContext.Param 读取同级参数时，得到的是经过上述过滤器（default 除外）处理后的值
+ */
+
+var (
+	tagRegexp   = regexp.MustCompile(`<[^>]*>`)
+	spaceRegexp = regexp.MustCompile(`\s+`)
+)
+
+func init() {
+	filters := map[string]func(string) string{
+		"trim":               strings.TrimSpace,
+		"lower":              strings.ToLower,
+		"upper":              strings.ToUpper,
+		"strip_tags":         stripTags,
+		"full_to_half_width": fullToHalfWidth,
+		"collapse_spaces":    collapseSpaces,
+	}
+	for name, fn := range filters {
+		ruleRegistry[name] = &ruleEntry{fn: filterRule(fn), filter: true}
+	}
+	ruleRegistry["default"] = &ruleEntry{fn: defaultValue, check: checkDefault, list: true}
+}
+
+// 将字符串处理方法转换为过滤器
+func filterRule(fn func(string) string) RuleFunc {
+	return func(c *RuleContext) error {
+		c.Value = fn(c.Value)
+		return nil
+	}
+}
+
+// 同级参数经过过滤器处理后的值，按规则顺序执行验证项中的过滤器，多值参数对每个值分别处理
+func (v *compiledItem) filterParam(c *RuleContext, path string) string {
+	item := v.item
+	item.Key = path
+	val, values := readItem(c.scope.src, &item)
+
+	fc := &RuleContext{Item: &item, ctx: c.ctx, config: c.config, scope: c.scope}
+	apply := func(s string) string {
+		for i, fn := range v.rules {
+			if !v.filter[i] {
+				continue
+			}
+			fc.Index, fc.Value = i, s
+			if fn(fc) != nil {
+				break
+			}
+			s = fc.Value
+		}
+		return s
+	}
+	if !item.Multiple {
+		return apply(val)
+	}
+	for i := range values {
+		values[i] = apply(values[i])
+	}
+	return strings.Join(nonEmpty(values), itemSeparator(&item))
+}
+
+func stripTags(val string) string {
+	return tagRegexp.ReplaceAllString(val, "")
+}
+
+func collapseSpaces(val string) string {
+	return spaceRegexp.ReplaceAllString(val, " ")
+}
+
+// 全角字符转为半角，全角空格转为半角空格
+func fullToHalfWidth(val string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\u3000':
+			return ' '
+		case r >= '\uff01' && r <= '\uff5e':
+			return r - 0xfee0
+		}
+		return r
+	}, val)
+}
+
+func checkDefault(data interface{}) error {
+	if _, ok := data.(string); !ok {
+		return fmt.Errorf(ConfigDataTypeNotAllow, data)
+	}
+	return nil
+}
+
+// 参数为空时使用默认值，多值参数没有值时使用默认值作为唯一的值
+func defaultValue(c *RuleContext) error {
+	def := c.Data().(string)
+	if c.Item.Multiple {
+		if len(c.Values) == 0 && def != "" {
+			c.Values = []string{def}
+			c.Value = def
+		}
+		return nil
+	}
+	if c.Value == "" {
+		c.Value = def
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/27 14:20
+ * @Desc:
+ */
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		rules  []ValidationRule
+		in     string
+		expect string
+	}{
+		{[]ValidationRule{{Rule: "trim"}}, "  booldesign \t", "booldesign"},
+		{[]ValidationRule{{Rule: "lower"}}, "BoolDesign", "booldesign"},
+		{[]ValidationRule{{Rule: "upper"}}, "BoolDesign", "BOOLDESIGN"},
+		{[]ValidationRule{{Rule: "strip_tags"}}, "<b>bool</b><br/>design", "booldesign"},
+		{[]ValidationRule{{Rule: "full_to_half_width"}}, "１３５０１６９１４３６　ＡＢ", "13501691436 AB"},
+		{[]ValidationRule{{Rule: "collapse_spaces"}}, "bool  \t design", "bool design"},
+		{[]ValidationRule{{Rule: "default", Data: "10"}}, "", "10"},
+		{[]ValidationRule{{Rule: "default", Data: "10"}}, "20", "20"},
+		{[]ValidationRule{{Rule: "trim"}, {Rule: "default", Data: "1"}}, "   ", "1"},
+	}
+
+	for _, test := range tests {
+		rules := []ValidationItem{{Key: "k", Name: "n", Rules: test.rules}}
+		data, _, err := Validation(testParams(map[string]string{"k": test.in}), rules)
+		if err != nil || data["k"] != test.expect {
+			t.Errorf("Validation(%v, %q) = %q, %v, want %q", test.rules, test.in, data["k"], err, test.expect)
+		}
+	}
+}
+
+func TestFiltersBeforeRules(t *testing.T) {
+	rules := []ValidationItem{
+		{Key: "mobile", Name: "手机号", Rules: []ValidationRule{
+			{Rule: "full_to_half_width"},
+			{Rule: "trim"},
+			{Rule: "required"},
+			{Rule: "regexp", Data: ValidationMobileData()},
+		}},
+		{Key: "tags", Name: "标签", Multiple: true, Rules: []ValidationRule{
+			{Rule: "trim"},
+			{Rule: "lower"},
+			{Rule: "in", Data: []string{"go", "rust"}},
+		}},
+		{Key: "pageSize", Name: "每页记录条数", Rules: []ValidationRule{
+			{Rule: "default", Data: "200"},
+			{Rule: "between", Data: []int{1, 100}},
+		}},
+	}
+
+	data, err := ValidationAll(testParams(map[string]string{"mobile": " １３５０１６９１４３６ ", "tags": " Go ,RUST"}), rules)
+	if data["mobile"] != "13501691436" || data["tags"] != "go,rust" {
+		t.Errorf("ValidationAll() unexpected data: %v", data)
+	}
+	// 默认值同样需要通过后续规则
+	if err == nil || err.(ValidationErrors).Get("pageSize") == nil {
+		t.Errorf("ValidationAll() should validate default value, got %v", err)
+	}
+}
+
+// 引用同级参数的规则使用经过过滤器处理后的值
+func TestFilteredParam(t *testing.T) {
+	rules := []ValidationItem{
+		{Key: "confirm", Name: "确认密码", Rules: []ValidationRule{{Rule: "eqfield", Data: "password"}}},
+		{Key: "password", Name: "密码", Rules: []ValidationRule{{Rule: "trim"}, {Rule: "required"}}},
+		{Key: "tags", Name: "标签", Multiple: true, Rules: []ValidationRule{{Rule: "trim"}, {Rule: "lower"}}},
+		{Key: "tagList", Name: "标签列表", Rules: []ValidationRule{{Rule: "eqfield", Data: "tags"}}},
+	}
+	tests := []struct {
+		params map[string]string
+		expect bool
+	}{
+		{map[string]string{"password": " secret ", "confirm": "secret"}, true},
+		{map[string]string{"password": " secret ", "confirm": " secret "}, false},
+		{map[string]string{"password": "secret", "tags": " Go , Rust", "tagList": "go,rust"}, true},
+	}
+
+	for _, test := range tests {
+		_, _, err := Validation(testParams(test.params), rules)
+		if (err == nil) != test.expect {
+			t.Errorf("Validation(%v) = %v", test.params, err)
+		}
+	}
+}
+
+// 多值参数中过滤后为空的值被去除，不计入后续规则
+func TestFilteredEmptyValues(t *testing.T) {
+	tests := []struct {
+		rules  []ValidationRule
+		src    Values
+		expect Values
+	}{
+		{[]ValidationRule{{Rule: "trim"}, {Rule: "required"}}, Values{"tags": {"  "}}, nil},
+		{[]ValidationRule{{Rule: "trim"}, {Rule: "required"}}, Values{"tags": {" go ", " "}}, Values{"tags": {"go"}}},
+		{[]ValidationRule{{Rule: "trim"}, {Rule: "min", Data: 2}}, Values{"tags": {"go", " "}}, nil},
+		{[]ValidationRule{{Rule: "trim"}, {Rule: "min", Data: 2}}, Values{"tags": {"go", " rust"}}, Values{"tags": {"go", "rust"}}},
+	}
+
+	for _, test := range tests {
+		schema := MustCompile([]ValidationItem{{Key: "tags", Name: "标签", Multiple: true, Rules: test.rules}})
+		values, err := schema.ValidateAllValues(test.src)
+		if test.expect == nil {
+			if err == nil {
+				t.Errorf("ValidateAllValues(%v, %v) = %v, want error", test.rules, test.src, values)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(values, test.expect) {
+			t.Errorf("ValidateAllValues(%v, %v) = %v, %v", test.rules, test.src, values, err)
+		}
+	}
+}
@@ -20,8 +20,16 @@ const (
 	DefaultErrData = "1000-01-01"
 )
 
-var (
-	loc, _ = time.LoadLocation(DefaultLocal) //设置时区
+const (
+	ValidateFuncFormatIncorrect = "%s 格式不正确"
+	ValidateFuncFormatError     = "%s 格式错误"
+	ValidateFuncIdsFormatError  = "%s  格式错误"
+	ValidateFuncInvalid         = "%s 错误"
+	ValidateFuncBirthdayRange   = "%s 必须介于 1905年 - 至今 之间"
+	ValidateFuncDateFormat      = "%s 格式错误：1000-01-01"
+	ValidateFuncUsername        = "%s 5~25位数字字母下划线组合，必须包含字母,不能以下划线开后和结尾"
+	ValidateFuncRealname        = "%s 1~20位中文，英文，字母,.的组合"
+	ValidateFuncPassword        = "%s 8~32位字母,数字,特殊符号的组合，且包含2种以上组合"
 )
 
 //自定义函数
@@ -49,7 +57,7 @@ func ValidationIdArrayData() ValidationFuncRule {
 			}
 			return true
 		},
-		"%s 格式不正确",
+		ValidateFuncFormatIncorrect,
 	}
 }
 
@@ -65,38 +73,58 @@ func ValidationTokenArrayData() ValidationFuncRule {
 			}
 			return true
 		},
-		"%s 格式不正确",
+		ValidateFuncFormatIncorrect,
 	}
 }
 
-// 检查生日格式
+// 检查生日格式，使用默认配置
 func ValidationBirthdayData() ValidationFuncRule {
 	return ValidationFuncRule{
 		func(val string) bool {
-			t, err := time.ParseInLocation(DefaultData, val, loc)
+			return DefaultConfig().BirthdayData().Func(val)
+		},
+		ValidateFuncBirthdayRange,
+	}
+}
+
+// 检查生日格式
+func (c *Config) BirthdayData() ValidationFuncRule {
+	return ValidationFuncRule{
+		func(val string) bool {
+			t, err := time.ParseInLocation(DefaultData, val, c.Loc())
 			if err != nil {
 				t, _ = time.Parse(DefaultData, DefaultErrData)
 			}
-			if t.Year() > 1905 && t.Year() < time.Now().In(loc).Year() {
+			if t.Year() > 1905 && t.Year() < c.Now().Year() {
 				return true
 			}
 			return false
 		},
-		"%s 必须介于 1905年 - 至今 之间",
+		ValidateFuncBirthdayRange,
 	}
 }
 
-// 检查日期格式
+// 检查日期格式，使用默认配置
 func ValidationDateData() ValidationFuncRule {
 	return ValidationFuncRule{
 		func(val string) bool {
-			_, err := time.ParseInLocation(DefaultData, val, loc)
+			return DefaultConfig().DateData().Func(val)
+		},
+		ValidateFuncDateFormat,
+	}
+}
+
+// 检查日期格式
+func (c *Config) DateData() ValidationFuncRule {
+	return ValidationFuncRule{
+		func(val string) bool {
+			_, err := time.ParseInLocation(DefaultData, val, c.Loc())
 			if err != nil {
 				return false
 			}
 			return true
 		},
-		"%s 格式错误：1000-01-01",
+		ValidateFuncDateFormat,
 	}
 }
 
@@ -104,41 +132,37 @@ func ValidationDateData() ValidationFuncRule {
 func ValidationIdCardCodeData() ValidationFuncRule {
 	return ValidationFuncRule{
 		ValifyIdCardCode,
-		"%s 格式错误",
+		ValidateFuncFormatError,
 	}
 }
 
-//验证身份证号码
+//验证身份证号码，支持 15 位号码，详见 ParseIDCard
 func ValifyIdCardCode(val string) bool {
-	if len(val) != 18 {
-		return false
-	}
+	_, err := ParseIDCard(val)
+	return err == nil
+}
 
-	// 加权因子
-	weight := []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
-	//校验码范围
-	checkCode := []string{"1", "0", "X", "9", "8", "7", "6", "5", "4", "3", "2"}
-	sum := 0
-	for i := 0; i < 17; i++ {
-		//第一步：前17位的本体码乘以加权因子：
-		v, _ := strconv.Atoi(string(val[i]))
-		sum = sum + v*weight[i]
+// 检查开始时间 不能在当前时间之后，使用默认配置
+func ValidationStartAtData() ValidationFuncRule {
+	return ValidationFuncRule{
+		func(val string) bool {
+			return DefaultConfig().StartAtData().Func(val)
+		},
+		ValidateFuncInvalid,
 	}
-	//第二步：校验码[求和后除以11(校验码的长度)的余数] == 身份证最后一位
-	return string(val[17]) == checkCode[sum%11]
 }
 
 // 检查开始时间 不能在当前时间之后
-func ValidationStartAtData() ValidationFuncRule {
+func (c *Config) StartAtData() ValidationFuncRule {
 	return ValidationFuncRule{
 		func(val string) bool {
 			intVal, err := strconv.Atoi(val)
-			if err == nil && intVal < int(time.Now().Unix()) {
+			if err == nil && intVal < int(c.Now().Unix()) {
 				return true
 			}
 			return false
 		},
-		"%s 错误",
+		ValidateFuncInvalid,
 	}
 }
 
@@ -146,7 +170,7 @@ func ValidationStartAtData() ValidationFuncRule {
 func ValidationMobileData() ValidationRegexpRule {
 	return ValidationRegexpRule{
 		`^(1[3-9]\d{9})$`,
-		"%s 格式不正确",
+		ValidateFuncFormatIncorrect,
 	}
 }
 
@@ -154,7 +178,7 @@ func ValidationMobileData() ValidationRegexpRule {
 func ValidationEmailData() ValidationRegexpRule {
 	return ValidationRegexpRule{
 		`^([\dA-Za-z_\.-]+)@([\dA-Za-z\.-]+)\.([A-Za-z\.]+)$`,
-		"%s 格式不正确",
+		ValidateFuncFormatIncorrect,
 	}
 }
 
@@ -199,7 +223,7 @@ func ValidationUsernameData() ValidationFuncRule {
 
 			return flag
 		},
-		"%s 5~25位数字字母下划线组合，必须包含字母,不能以下划线开后和结尾",
+		ValidateFuncUsername,
 	}
 }
 
@@ -222,7 +246,7 @@ func ValidationRealnameData() ValidationFuncRule {
 			}
 			return flag
 		},
-		"%s 1~20位中文，英文，字母,.的组合",
+		ValidateFuncRealname,
 	}
 }
 
@@ -235,7 +259,7 @@ func ValidationObjectId() ValidationFuncRule {
 			}
 			return CheckMongoIdFormat(val)
 		},
-		"%s 格式错误",
+		ValidateFuncFormatError,
 	}
 }
 
@@ -264,7 +288,7 @@ func ValidationPasswordData() ValidationFuncRule {
 			}
 			return false
 		},
-		"%s 8~32位字母,数字,特殊符号的组合，且包含2种以上组合",
+		ValidateFuncPassword,
 	}
 }
 
@@ -283,7 +307,7 @@ func ValidationObjectIds() ValidationFuncRule {
 			}
 			return true
 		},
-		"%s  格式错误",
+		ValidateFuncIdsFormatError,
 	}
 }
 
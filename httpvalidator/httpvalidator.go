@@ -0,0 +1,158 @@
+package httpvalidator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/booldesign/validator"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/6 10:40
+ * @Desc: net/http 请求参数验证及错误响应
+ */
+
+const (
+	CodeRequestParamsInvalid  = "request.params.invalid"
+	CodeValidationRuleInvalid = "validation.rule.invalid"
+	CodeValidationRuleFailed  = "validation.rule.failed"
+)
+
+// 解析 multipart 表单时使用的最大内存
+const DefaultMaxMemory = 32 << 20
+
+type Error struct {
+	Code    string            `json:"code"`    // 错误代码
+	Message string            `json:"message"` // 错误信息
+	Fields  map[string]string `json:"fields"`  // 错误字段信息
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// 解析请求参数，合并 query、application/x-www-form-urlencoded 及 multipart/form-data 表单
+func Values(r *http.Request) (url.Values, error) {
+	if r.Form != nil {
+		return r.Form, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		if err := r.ParseMultipartForm(DefaultMaxMemory); err != nil {
+			return nil, err
+		}
+		return r.Form, nil
+	}
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	return r.Form, nil
+}
+
+// 请求参数来源，application/json 请求使用 JSON 请求体，其他请求使用 Values 合并的表单参数
+func Source(r *http.Request) (validator.Source, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" && r.Body != nil {
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, DefaultMaxMemory))
+		if err != nil {
+			return nil, err
+		}
+		src, err := validator.ParseJSON(body)
+		if err != nil {
+			return nil, err
+		}
+		return src, nil
+	}
+
+	values, err := Values(r)
+	if err != nil {
+		return nil, err
+	}
+	return validator.Values(values), nil
+}
+
+// 将请求参数转换为 validator 使用的参数方法，同名参数多次提交时以 validator.DefaultSeparator 连接
+func Params(values url.Values) func(string) string {
+	return func(key string) string {
+		return strings.Join(values[key], validator.DefaultSeparator)
+	}
+}
+
+// 请求参数验证，返回全部错误，错误信息语言根据 Accept-Language 选择
+// ValidationItem.Multiple 为 true 的参数按同名参数的全部值验证，JSON 请求体的参数键为 JSON 路径
+func Validate(r *http.Request, schema *validator.Schema, opts ...validator.Option) (map[string]string, error) {
+	src, err := Source(r)
+	if err != nil {
+		return nil, err
+	}
+	return schema.ValidateAllSource(src, requestOptions(r, opts)...)
+}
+
+// 请求参数验证并绑定到结构体，验证规则来自结构体标签
+func Bind(r *http.Request, dst interface{}, opts ...validator.Option) error {
+	src, err := Source(r)
+	if err != nil {
+		return err
+	}
+	return validator.BindSource(src, dst, requestOptions(r, opts)...)
+}
+
+// 根据 Accept-Language 选择语言，规则使用请求的 context，调用方传入的选项优先
+func requestOptions(r *http.Request, opts []validator.Option) []validator.Option {
+	locale := validator.MatchLocale(r.Header.Get("Accept-Language"))
+	return append([]validator.Option{validator.WithLocale(locale), validator.WithContext(r.Context())}, opts...)
+}
+
+// 将验证错误转换为响应结构，非验证错误返回 nil
+func NewError(err error) *Error {
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) && len(errs) > 0 {
+		return &Error{
+			Code:    CodeRequestParamsInvalid,
+			Message: errs.Flatten()[0].Errors[0].Error(),
+			Fields:  errs.Fields(),
+		}
+	}
+
+	var fe *validator.FieldError
+	if errors.As(err, &fe) {
+		return &Error{
+			Code:    CodeRequestParamsInvalid,
+			Message: fe.Error(),
+			Fields:  map[string]string{fe.Key: fe.Error()},
+		}
+	}
+	return nil
+}
+
+// 输出错误响应，规则配置错误及规则执行错误为 500，验证错误及请求参数解析错误为 400
+// 规则执行错误可能包含数据库等内部信息，不输出具体错误
+func WriteError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	body := NewError(err)
+	var ce *validator.ConfigError
+	var re *validator.RuleError
+	if errors.As(err, &ce) {
+		status = http.StatusInternalServerError
+		body = &Error{Code: CodeValidationRuleInvalid, Message: err.Error()}
+	} else if errors.As(err, &re) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		status = http.StatusInternalServerError
+		body = &Error{Code: CodeValidationRuleFailed, Message: http.StatusText(status)}
+	} else if body == nil {
+		body = &Error{Code: CodeRequestParamsInvalid, Message: err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
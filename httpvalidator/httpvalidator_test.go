@@ -0,0 +1,132 @@
+package httpvalidator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/booldesign/validator"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/6 15:12
+ * @Desc:
+ */
+
+var testSchema = validator.MustCompile([]validator.ValidationItem{
+	{Key: "orgId", Name: "orgId", Rules: []validator.ValidationRule{{Rule: "required"}, {Rule: "integer"}}},
+	{Key: "ids", Name: "ids", Rules: []validator.ValidationRule{{Rule: "arrayInArray", Data: []interface{}{",", []int{1, 2, 3}}}}},
+	{Key: "keywords", Name: "keywords", Rules: []validator.ValidationRule{{Rule: "max", Data: "5"}}},
+})
+
+func TestValidateQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/?orgId=1&ids=1&ids=3&keywords=go", nil)
+	data, err := Validate(r, testSchema)
+	if err != nil {
+		t.Fatalf("Validate() failed. %v", err)
+	}
+	if data["ids"] != "1,3" || data["orgId"] != "1" {
+		t.Errorf("Validate() unexpected data: %v", data)
+	}
+}
+
+func TestValidatePostForm(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/?orgId=1", strings.NewReader("ids=1&ids=4&keywords=golang"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("Accept-Language", "en-US,en;q=0.9")
+
+	_, err := Validate(r, testSchema)
+	if err == nil {
+		t.Fatal("Validate() should fail.")
+	}
+
+	w := httptest.NewRecorder()
+	WriteError(w, err)
+	var body Error
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("WriteError() wrote invalid json. %v", err)
+	}
+	if w.Code != http.StatusBadRequest || body.Code != CodeRequestParamsInvalid || len(body.Fields) != 2 {
+		t.Errorf("WriteError() unexpected response: %d %+v", w.Code, body)
+	}
+	if body.Fields["keywords"] != "keywords must be at most 5 characters long" {
+		t.Errorf("WriteError() should translate by Accept-Language, got %s", body.Fields["keywords"])
+	}
+}
+
+func TestValidateMultipart(t *testing.T) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	_ = mw.WriteField("orgId", "a")
+	_ = mw.WriteField("ids", "2")
+	_ = mw.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/", &buf)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+
+	_, err := Validate(r, testSchema)
+	e := NewError(err)
+	if e == nil || len(e.Fields) != 1 || e.Fields["orgId"] == "" {
+		t.Errorf("Validate() multipart unexpected error: %v", err)
+	}
+}
+
+func TestWriteConfigError(t *testing.T) {
+	_, err := validator.Compile([]validator.ValidationItem{{Key: "a", Rules: []validator.ValidationRule{{Rule: "unknown"}}}})
+	w := httptest.NewRecorder()
+	WriteError(w, err)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("WriteError() config error status = %d", w.Code)
+	}
+}
+
+func TestWriteRuleError(t *testing.T) {
+	schema := validator.MustCompile([]validator.ValidationItem{
+		{Key: "username", Rules: []validator.ValidationRule{{Rule: "func", Data: validator.ValidationContextFuncRule{
+			Func: func(ctx context.Context, val string) error {
+				return errors.New("connection refused")
+			},
+			Msg: "%s 已被使用",
+		}}}},
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/?username=bool", nil)
+	_, err := Validate(r, schema)
+	w := httptest.NewRecorder()
+	WriteError(w, err)
+	if w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), "connection refused") {
+		t.Errorf("WriteError() rule error = %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestValidateJSON(t *testing.T) {
+	schema := validator.MustCompile([]validator.ValidationItem{
+		{Key: "items[*].sku", Name: "sku", Rules: []validator.ValidationRule{{Rule: "required"}}},
+	})
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"items": [{"sku": "A"}, {"sku": ""}]}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	_, err := Validate(r, schema)
+	e := NewError(err)
+	if e == nil || e.Fields["items[1].sku"] == "" {
+		t.Errorf("Validate() json unexpected error: %v", err)
+	}
+}
+
+func TestSourceInvalidJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"items":`))
+	r.Header.Set("Content-Type", "application/json")
+
+	src, err := Source(r)
+	if err == nil || src != nil {
+		t.Errorf("Source() = %#v, %v, want nil source and error", src, err)
+	}
+}
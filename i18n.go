@@ -0,0 +1,191 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/14 15:02
+ * @Desc: 错误信息国际化
+ */
+
+const DefaultLanguage = "zh-CN"
+
+// 错误信息翻译器
+type Translator interface {
+	// 将错误翻译为指定语言，没有对应翻译时返回 false
+	Translate(locale string, fe *FieldError) (string, bool)
+}
+
+// 翻译目录，翻译键 => 错误信息格式，第一个占位符为参数名称
+// 翻译键依次匹配 FieldError.MsgKey 和 FieldError.Rule
+type Catalog map[string]string
+
+// 基于翻译目录的翻译器
+type CatalogTranslator struct {
+	mu       sync.RWMutex
+	catalogs map[string]Catalog // 规范化的语言 => 翻译目录
+	names    map[string]string  // 规范化的语言 => 注册时的语言名称
+}
+
+var defaultTranslator = NewCatalogTranslator()
+
+func init() {
+	defaultTranslator.Register("zh-CN", catalogZhCN)
+	defaultTranslator.Register("en-US", catalogEnUS)
+}
+
+func NewCatalogTranslator() *CatalogTranslator {
+	return &CatalogTranslator{
+		catalogs: map[string]Catalog{},
+		names:    map[string]string{},
+	}
+}
+
+// 注册翻译目录，同一语言多次注册时合并，后注册的覆盖先注册的
+func (t *CatalogTranslator) Register(locale string, c Catalog) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := normalizeLocale(locale)
+	catalog, ok := t.catalogs[key]
+	if !ok {
+		catalog = Catalog{}
+		t.catalogs[key] = catalog
+		t.names[key] = locale
+	}
+	for k, v := range c {
+		catalog[k] = v
+	}
+}
+
+// 已注册的语言
+func (t *CatalogTranslator) Locales() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	locales := make([]string, 0, len(t.names))
+	for _, name := range t.names {
+		locales = append(locales, name)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+func (t *CatalogTranslator) Translate(locale string, fe *FieldError) (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	key, ok := t.lookup(locale)
+	if !ok {
+		return "", false
+	}
+	catalog := t.catalogs[key]
+	format, ok := catalog[fe.MsgKey]
+	if !ok || fe.MsgKey == "" {
+		if format, ok = catalog[fe.Rule]; !ok {
+			return "", false
+		}
+	}
+	return fmt.Sprintf(format, append([]interface{}{fe.Name}, fe.Args...)...), true
+}
+
+// 根据 Accept-Language 选择最合适的已注册语言，没有匹配时返回 DefaultLanguage
+func (t *CatalogTranslator) Match(acceptLanguage string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, locale := range parseAcceptLanguage(acceptLanguage) {
+		if key, ok := t.lookup(locale); ok {
+			return t.names[key]
+		}
+	}
+	return DefaultLanguage
+}
+
+// 查找已注册的语言，先精确匹配，再按语种匹配
+func (t *CatalogTranslator) lookup(locale string) (string, bool) {
+	key := normalizeLocale(locale)
+	if _, ok := t.catalogs[key]; ok {
+		return key, true
+	}
+
+	base := strings.SplitN(key, "-", 2)[0]
+	candidates := make([]string, 0, 1)
+	for k := range t.catalogs {
+		if strings.SplitN(k, "-", 2)[0] == base {
+			candidates = append(candidates, k)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	sort.Strings(candidates)
+	return candidates[0], true
+}
+
+// 向默认翻译器注册翻译目录，可用于补充新语言或覆盖内置信息
+func RegisterCatalog(locale string, c Catalog) {
+	defaultTranslator.Register(locale, c)
+}
+
+// 根据 Accept-Language 从默认翻译器中选择语言
+func MatchLocale(acceptLanguage string) string {
+	return defaultTranslator.Match(acceptLanguage)
+}
+
+// 使用指定翻译器翻译错误信息，没有对应翻译时返回原错误信息
+func (e *FieldError) Translate(t Translator, locale string) string {
+	if msg, ok := t.Translate(locale, e); ok {
+		return msg
+	}
+	return e.Error()
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(locale), "_", "-", -1))
+}
+
+// 解析 Accept-Language，按权重从高到低返回语言列表
+func parseAcceptLanguage(header string) []string {
+	type language struct {
+		tag string
+		q   float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if v, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			languages = append(languages, language{tag, q})
+		}
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].q > languages[j].q
+	})
+	tags := make([]string, len(languages))
+	for i, l := range languages {
+		tags[i] = l.tag
+	}
+	return tags
+}
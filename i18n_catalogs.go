@@ -0,0 +1,144 @@
+package validator
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/14 16:40
+ * @Desc: 内置翻译目录
+ */
+
+var catalogZhCN = Catalog{
+	MsgRequired:     ValidateValCanNotEmpty,
+	MsgIn:           ValidateValNotExists,
+	MsgBool:         ValidateValMustBool,
+	MsgInteger:      ValidateValMustInteger,
+	MsgBetweenInt:   ValidateValNotBetweenInt,
+	MsgBetweenFloat: ValidateValNotBetweenFloat,
+	MsgBetweenStr:   ValidateValNotBetweenStr,
+	MsgMinInt:       ValidateValNotMinInt,
+	MsgMinFloat:     ValidateValNotMinFloat,
+	MsgMinStr:       ValidateValNotMinStr,
+	MsgMaxInt:       ValidateValNotMaxInt,
+	MsgMaxFloat:     ValidateValNotMaxFloat,
+	MsgMaxStr:       ValidateValNotMaxStr,
+	MsgArrayInArray: ValidateValArrayNotInArray,
+	MsgFilterChar:   ValidateValExistsFilterChar,
+	MsgDistinct:     ValidateValMustDistinct,
+	MsgBetweenCount: ValidateValNotBetweenCount,
+	MsgMinCount:     ValidateValNotMinCount,
+	MsgMaxCount:     ValidateValNotMaxCount,
+	MsgBind:         ValidateValBindFailed,
+
+	MsgRequiredIf:      ValidateValRequiredIf,
+	MsgRequiredUnless:  ValidateValRequiredUnless,
+	MsgRequiredWith:    ValidateValRequiredWith,
+	MsgRequiredWithAll: ValidateValRequiredWithAll,
+	MsgRequiredWithout: ValidateValRequiredWithout,
+
+	MsgEqField:   ValidateValEqField,
+	MsgDifferent: ValidateValDifferent,
+	MsgGtField:   ValidateValGtField,
+	MsgGteField:  ValidateValGteField,
+	MsgLtField:   ValidateValLtField,
+	MsgLteField:  ValidateValLteField,
+
+	MsgPresent: ValidateValMustPresent,
+	MsgFilled:  ValidateValMustFilled,
+
+	MsgDate:          ValidateValDate,
+	MsgUnix:          ValidateValUnix,
+	MsgBefore:        ValidateValBefore,
+	MsgAfter:         ValidateValAfter,
+	MsgBeforeOrEqual: ValidateValBeforeOrEqual,
+	MsgAfterOrEqual:  ValidateValAfterOrEqual,
+	MsgWithin:        ValidateValWithin,
+	MsgTimeRef:       ValidateValTimeRef,
+
+	MsgRangeOrder:   ValidateValRangeOrder,
+	MsgRangeBefore:  ValidateValRangeBefore,
+	MsgRangeInvalid: ValidateValRangeInvalid,
+	MsgRangeMaxSpan: ValidateValRangeMaxSpan,
+	MsgRangeMinSpan: ValidateValRangeMinSpan,
+	MsgRangeFuture:  ValidateValRangeFuture,
+
+	MsgAgeBetween: ValidateValAgeBetween,
+	MsgAgeMin:     ValidateValAgeMin,
+	MsgAgeInvalid: ValidateValAgeInvalid,
+
+	MsgIDCard: ValidateValIDCard,
+}
+
+var catalogEnUS = Catalog{
+	MsgRequired:     "%s is required",
+	MsgIn:           "%s does not exist",
+	MsgBool:         "%s must be true or false",
+	MsgInteger:      "%s must be an integer",
+	MsgBetweenInt:   "%s must be an integer between %d and %d",
+	MsgBetweenFloat: "%s must be a number between %f and %f",
+	MsgBetweenStr:   "%s must be between %d and %d characters long",
+	MsgMinInt:       "%s must be an integer greater than or equal to %d",
+	MsgMinFloat:     "%s must be a number greater than or equal to %f",
+	MsgMinStr:       "%s must be at least %d characters long",
+	MsgMaxInt:       "%s must be an integer less than or equal to %d",
+	MsgMaxFloat:     "%s must be a number less than or equal to %f",
+	MsgMaxStr:       "%s must be at most %d characters long",
+	MsgArrayInArray: "%s must not contain values other than %v",
+	MsgFilterChar:   "%s must not contain %v",
+	MsgDistinct:     "%s contains the duplicate value [%s]",
+	MsgBetweenCount: "%s must contain between %d and %d items",
+	MsgMinCount:     "%s must contain at least %d items",
+	MsgMaxCount:     "%s must contain at most %d items",
+	MsgBind:         "%s has an invalid format",
+	"func":          "%s is invalid",
+
+	MsgRequiredIf:      "%s is required when %s is %s",
+	MsgRequiredUnless:  "%s is required unless %s is %s",
+	MsgRequiredWith:    "%s is required when %s is present",
+	MsgRequiredWithAll: "%s is required when %s are all present",
+	MsgRequiredWithout: "%s is required when %s is not present",
+
+	MsgEqField:   "%s must be the same as %s",
+	MsgDifferent: "%s must be different from %s",
+	MsgGtField:   "%s must be greater than %s",
+	MsgGteField:  "%s must be greater than or equal to %s",
+	MsgLtField:   "%s must be less than %s",
+	MsgLteField:  "%s must be less than or equal to %s",
+
+	MsgPresent: "%s must be present",
+	MsgFilled:  "%s must not be empty when present",
+
+	MsgDate:          "%s must be a time formatted like %s",
+	MsgUnix:          "%s must be a timestamp",
+	MsgBefore:        "%s must be before %s",
+	MsgAfter:         "%s must be after %s",
+	MsgBeforeOrEqual: "%s must not be after %s",
+	MsgAfterOrEqual:  "%s must not be before %s",
+	MsgWithin:        "%s must be between %s and %s",
+	MsgTimeRef:       "%s: %s is not a valid time",
+
+	MsgRangeOrder:   "%s must not be before %s",
+	MsgRangeBefore:  "%s must not be after %s",
+	MsgRangeInvalid: "%s: %s is not a valid time",
+	MsgRangeMaxSpan: "%s must be within %[3]s of %[2]s",
+	MsgRangeMinSpan: "%s must be at least %[3]s after %[2]s",
+	MsgRangeFuture:  "%s must not be in the future",
+
+	MsgAgeBetween: "%s must correspond to an age between %d and %d",
+	MsgAgeMin:     "%s must correspond to an age of at least %d",
+	MsgAgeInvalid: "%s is not a valid birth date or ID card number",
+
+	MsgIDCard: "%s is not a valid ID card number",
+
+	"regexp": "%s has an invalid format",
+
+	// func_extends 中自定义规则的错误信息
+	ValidateFuncFormatIncorrect: "%s has an invalid format",
+	ValidateFuncFormatError:     "%s has an invalid format",
+	ValidateFuncIdsFormatError:  "%s has an invalid format",
+	ValidateFuncInvalid:         "%s is invalid",
+	ValidateFuncBirthdayRange:   "%s must be between 1905 and now",
+	ValidateFuncDateFormat:      "%s must be formatted like 1000-01-01",
+	ValidateFuncUsername:        "%s must be 5-25 letters, digits or underscores, contain a letter and not start or end with an underscore",
+	ValidateFuncRealname:        "%s must be 1-20 Chinese characters, letters, digits or dots",
+	ValidateFuncPassword:        "%s must be 8-32 letters, digits or symbols, combining at least 2 of them",
+}
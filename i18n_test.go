@@ -0,0 +1,79 @@
+package validator
+
+import "testing"
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/14 17:20
+ * @Desc:
+ */
+
+func TestMatchLocale(t *testing.T) {
+	tests := []struct {
+		in     string
+		expect string
+	}{
+		{"en-US,en;q=0.9", "en-US"},
+		{"en-GB", "en-US"},
+		{"ja;q=0.9,en;q=0.8", "en-US"},
+		{"zh_cn", "zh-CN"},
+		{"fr-FR", DefaultLanguage},
+		{"", DefaultLanguage},
+	}
+
+	for _, test := range tests {
+		if locale := MatchLocale(test.in); locale != test.expect {
+			t.Errorf("MatchLocale(%q) = %s, want %s", test.in, locale, test.expect)
+		}
+	}
+}
+
+func TestValidationWithLocale(t *testing.T) {
+	rules := []ValidationItem{
+		{Key: "pageSize", Name: "pageSize", Rules: []ValidationRule{{Rule: "between", Data: []int{1, 100}}}},
+		{Key: "mobile", Name: "mobile", Rules: []ValidationRule{{Rule: "regexp", Data: ValidationMobileData()}}},
+	}
+	params := testParams(map[string]string{"pageSize": "0", "mobile": "123"})
+
+	tests := []struct {
+		locale string
+		expect []string
+	}{
+		{"en-US", []string{"pageSize must be an integer between 1 and 100", "mobile has an invalid format"}},
+		{"zh-CN", []string{"pageSize 必须是 1 - 100 之间的整数", "mobile 格式不正确"}},
+		{"", []string{"pageSize 必须是 1 - 100 之间的整数", "mobile 格式不正确"}},
+	}
+
+	for _, test := range tests {
+		_, err := ValidationAll(params, rules, WithLocale(test.locale))
+		errs := err.(ValidationErrors)
+		if len(errs) != len(test.expect) {
+			t.Fatalf("ValidationAll(%s) returned %d errors, want %d", test.locale, len(errs), len(test.expect))
+		}
+		for i, item := range errs {
+			if msg := item.Errors[0].Error(); msg != test.expect[i] {
+				t.Errorf("ValidationAll(%s) = %s, want %s", test.locale, msg, test.expect[i])
+			}
+		}
+	}
+}
+
+func TestRegisterCatalog(t *testing.T) {
+	translator := NewCatalogTranslator()
+	translator.Register("ja-JP", Catalog{MsgRequired: "%s は必須です"})
+
+	rules := []ValidationItem{{Key: "name", Name: "名前", Rules: []ValidationRule{{Rule: "required"}}}}
+	_, _, err := Validation(testParams(nil), rules, WithTranslator(translator), WithLocale("ja"))
+	if err == nil || err.Error() != "名前 は必須です" {
+		t.Errorf("Validation() with ja translator = %v", err)
+	}
+}
+
+func TestObjectIdsMessage(t *testing.T) {
+	rules := []ValidationItem{{Key: "ids", Name: "ids", Rules: []ValidationRule{{Rule: "func", Data: ValidationObjectIds()}}}}
+	_, _, err := Validation(testParams(map[string]string{"ids": "x"}), rules)
+	if err == nil || err.Error() != "ids  格式错误" {
+		t.Errorf("Validation() = %q", err)
+	}
+}
@@ -0,0 +1,249 @@
+package validator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/29 10:15
+ * @Desc: 居民身份证号码（GB 11643）验证及解析
+ *
+ * {Rule: "idcard"}  15 或 18 位身份证号码，校验字符、出生日期、地区代码及校验码，末位 x 视为 X
+ *
+ * 地区代码按 GB/T 2260 验证到区县，内置数据含已撤销的历史代码（已撤销的区县代码仍出现在有效的身份证上），
+ * 以及港澳台居民居住证使用的 810000、820000、830000；
+ * 内置数据缺少的代码可以使用 LoadRegions 或 RegisterRegions 补充，补充的代码与内置数据合并
+ */
+
+const (
+	IDCardLengthInvalid   = "身份证号码必须为 15 或 18 位"
+	IDCardCharInvalid     = "身份证号码第 %d 位 %q 不合法"
+	IDCardBirthdayInvalid = "身份证号码中的出生日期 %s 不合法"
+	IDCardRegionInvalid   = "身份证号码中的地区代码 %s 不存在"
+	IDCardChecksumInvalid = "身份证号码校验码应为 %c"
+	RegionLineInvalid     = "行政区划代码第 %d 行 %q 不合法"
+	ValidateValIDCard     = "%s 不是有效的身份证号码"
+)
+
+const MsgIDCard = "idcard"
+
+const (
+	GenderMale   = "male"
+	GenderFemale = "female"
+)
+
+// 解析后的身份证信息
+type IDCard struct {
+	Number   string    // 18 位号码，15 位号码升级为 18 位，末位 x 转为 X
+	Legacy   bool      // 是否为 15 位号码
+	Region   string    // 6 位地区代码
+	Province string    // 省级行政区名称
+	Area     string    // 地区名称
+	Birthday time.Time // 出生日期
+	Gender   string    // 性别，GenderMale 或 GenderFemale
+}
+
+// GB/T 2260 省级行政区划代码，81、82、83 为港澳台居民居住证使用的代码
+var provinces = map[string]string{
+	"11": "北京市", "12": "天津市", "13": "河北省", "14": "山西省", "15": "内蒙古自治区",
+	"21": "辽宁省", "22": "吉林省", "23": "黑龙江省",
+	"31": "上海市", "32": "江苏省", "33": "浙江省", "34": "安徽省", "35": "福建省", "36": "江西省", "37": "山东省",
+	"41": "河南省", "42": "湖北省", "43": "湖南省", "44": "广东省", "45": "广西壮族自治区", "46": "海南省",
+	"50": "重庆市", "51": "四川省", "52": "贵州省", "53": "云南省", "54": "西藏自治区",
+	"61": "陕西省", "62": "甘肃省", "63": "青海省", "64": "宁夏回族自治区", "65": "新疆维吾尔自治区",
+	"71": "台湾省", "81": "香港特别行政区", "82": "澳门特别行政区", "83": "台湾地区",
+}
+
+var (
+	regionMu sync.RWMutex
+	regions  map[string]string // 6 位行政区划代码 => 名称，初始为内置数据
+)
+
+// 加权因子及校验码
+var (
+	idCardWeights = [17]int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	idCardChecks  = "10X98765432"
+)
+
+func init() {
+	ruleRegistry["idcard"] = &ruleEntry{fn: validationIDCard}
+
+	list, err := readRegions(strings.NewReader(builtinRegionData))
+	if err != nil {
+		panic(err)
+	}
+	regions = list
+}
+
+// 注册行政区划代码，code 为 6 位代码，与内置数据合并，代码已存在时替换名称
+func RegisterRegions(list map[string]string) {
+	regionMu.Lock()
+	defer regionMu.Unlock()
+
+	for code, name := range list {
+		regions[code] = name
+	}
+}
+
+// 从每行一个 "代码 名称" 的文本加载行政区划代码，代码与名称以空白、逗号或制表符分隔，# 开头的行为注释
+// 加载的代码与内置数据合并，任一行不合法时不加载
+func LoadRegions(r io.Reader) error {
+	list, err := readRegions(r)
+	if err != nil {
+		return err
+	}
+	RegisterRegions(list)
+	return nil
+}
+
+// 读取每行一个 "代码 名称" 的行政区划代码
+func readRegions(r io.Reader) (map[string]string, error) {
+	list := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == '\t' || r == ' '
+		})
+		if len(fields) < 2 || !isDigits(fields[0]) || len(fields[0]) != 6 {
+			return nil, fmt.Errorf(RegionLineInvalid, n, line)
+		}
+		list[fields[0]] = fields[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// 地区代码对应的省级行政区及地区名称
+func lookupRegion(code string) (string, string, bool) {
+	province, ok := provinces[code[:2]]
+	if !ok {
+		return "", "", false
+	}
+
+	regionMu.RLock()
+	defer regionMu.RUnlock()
+	area, ok := regions[code]
+	return province, area, ok
+}
+
+// 使用默认配置解析身份证号码
+func ParseIDCard(val string) (*IDCard, error) {
+	return DefaultConfig().ParseIDCard(val)
+}
+
+// 解析身份证号码，出生日期按配置的时区解析，且不能晚于配置时钟的当天
+func (c *Config) ParseIDCard(val string) (*IDCard, error) {
+	number, err := normalizeIDCard(val)
+	if err != nil {
+		return nil, err
+	}
+	legacy := len(number) == 15
+
+	birth := number[6:14]
+	if legacy {
+		birth = "19" + number[6:12]
+	}
+	birthday, err := time.ParseInLocation("20060102", birth, c.Loc())
+	if err != nil || birthday.Year() < 1900 {
+		return nil, fmt.Errorf(IDCardBirthdayInvalid, birth)
+	}
+	if _, ok := ageAt(birthday, c.Now()); !ok {
+		return nil, fmt.Errorf(IDCardBirthdayInvalid, birth)
+	}
+
+	province, area, ok := lookupRegion(number[:6])
+	if !ok {
+		return nil, fmt.Errorf(IDCardRegionInvalid, number[:6])
+	}
+
+	if legacy {
+		number = number[:6] + "19" + number[6:]
+		number += string(idCardCheckCode(number))
+	} else if check := idCardCheckCode(number[:17]); number[17] != check {
+		return nil, fmt.Errorf(IDCardChecksumInvalid, check)
+	}
+
+	gender := GenderFemale
+	if (number[16]-'0')%2 == 1 {
+		gender = GenderMale
+	}
+	return &IDCard{
+		Number:   number,
+		Legacy:   legacy,
+		Region:   number[:6],
+		Province: province,
+		Area:     area,
+		Birthday: birthday,
+		Gender:   gender,
+	}, nil
+}
+
+// 将 15 位身份证号码升级为 18 位，18 位号码验证后原样返回
+func UpgradeIDCard(val string) (string, error) {
+	card, err := ParseIDCard(val)
+	if err != nil {
+		return "", err
+	}
+	return card.Number, nil
+}
+
+// 检查长度及字符，18 位号码末位可以是 X 或 x，返回末位转为大写的号码
+func normalizeIDCard(val string) (string, error) {
+	if len(val) != 15 && len(val) != 18 {
+		return "", fmt.Errorf(IDCardLengthInvalid)
+	}
+	for i := 0; i < len(val); i++ {
+		ch := val[i]
+		if ch >= '0' && ch <= '9' {
+			continue
+		}
+		if i == 17 && (ch == 'X' || ch == 'x') {
+			return val[:17] + "X", nil
+		}
+		return "", fmt.Errorf(IDCardCharInvalid, i+1, ch)
+	}
+	return val, nil
+}
+
+// 前 17 位本体码的校验码：本体码乘以加权因子求和后除以 11 的余数对应的字符
+func idCardCheckCode(body string) byte {
+	sum := 0
+	for i := 0; i < 17; i++ {
+		sum += int(body[i]-'0') * idCardWeights[i]
+	}
+	return idCardChecks[sum%11]
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func validationIDCard(c *RuleContext) error {
+	if c.Value == "" {
+		return nil
+	}
+	if _, err := c.Config().ParseIDCard(c.Value); err != nil {
+		fe := newFieldError(c.Item, c.Index, c.Value, MsgIDCard, ValidateValIDCard)
+		fe.Err = err
+		return fe
+	}
+	return nil
+}
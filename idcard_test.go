@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/29 16:00
+ * @Desc:
+ */
+
+func TestParseIDCard(t *testing.T) {
+	card, err := ParseIDCard("11010519491231002x")
+	if err != nil {
+		t.Fatalf("ParseIDCard() failed. %v", err)
+	}
+	if card.Number != "11010519491231002X" || card.Region != "110105" || card.Province != "北京市" ||
+		card.Gender != GenderFemale || card.Birthday.Format(DefaultData) != "1949-12-31" || card.Legacy {
+		t.Errorf("ParseIDCard() = %+v", card)
+	}
+
+	card, err = ParseIDCard("110101850101001")
+	if err != nil {
+		t.Fatalf("ParseIDCard(15) failed. %v", err)
+	}
+	if card.Number != "110101198501010017" || !card.Legacy || card.Gender != GenderMale {
+		t.Errorf("ParseIDCard(15) = %+v", card)
+	}
+	if number, _ := UpgradeIDCard("110101850101001"); number != "110101198501010017" {
+		t.Errorf("UpgradeIDCard() = %s", number)
+	}
+}
+
+func TestParseIDCardError(t *testing.T) {
+	tests := []struct {
+		in  string
+		msg string
+	}{
+		{"11010120030101001", IDCardLengthInvalid},
+		{"11010120030101a012", "第 15 位"},
+		{"1101012003010100x2", "第 17 位"},
+		{"-10101200301010012", "第 1 位"},
+		{"110101202302300012", "出生日期 20230230"},
+		{"110101189912310012", "出生日期 18991231"},
+		{"990101200301010012", "地区代码 990101"},
+		{"110101200301010011", "校验码应为 2"},
+		{"110101850230001", "出生日期 19850230"},
+	}
+	cfg := configAt(2021, 1, 1)
+	for _, test := range tests {
+		if _, err := cfg.ParseIDCard(test.in); err == nil || !strings.Contains(err.Error(), test.msg) {
+			t.Errorf("ParseIDCard(%s) = %v", test.in, err)
+		}
+	}
+
+	// 出生日期不能晚于当天
+	if _, err := cfg.ParseIDCard("440304202101020014"); err == nil {
+		t.Error("ParseIDCard() future birthday should fail")
+	}
+}
+
+func TestIDCardRegions(t *testing.T) {
+	regionMu.RLock()
+	saved := make(map[string]string, len(regions))
+	for code, name := range regions {
+		saved[code] = name
+	}
+	regionMu.RUnlock()
+	defer func() {
+		regionMu.Lock()
+		regions = saved
+		regionMu.Unlock()
+	}()
+
+	// 内置数据验证到区县，含已撤销的代码
+	card, err := ParseIDCard("11010519491231002X")
+	if err != nil || card.Area != "朝阳区" {
+		t.Errorf("ParseIDCard() = %+v, %v", card, err)
+	}
+	if card, err := ParseIDCard("110103850101001"); err != nil || card.Area != "崇文区" {
+		t.Errorf("ParseIDCard(revoked) = %+v, %v", card, err)
+	}
+	// 省级代码存在但区县代码不存在
+	if _, err := ParseIDCard("119999850101001"); err == nil {
+		t.Error("ParseIDCard() unknown region should fail")
+	}
+
+	if err := LoadRegions(strings.NewReader("# GB/T 2260\n119999,测试区\n110105\t朝阳\n")); err != nil {
+		t.Fatalf("LoadRegions() failed. %v", err)
+	}
+	if card, err := ParseIDCard("119999850101001"); err != nil || card.Area != "测试区" {
+		t.Errorf("ParseIDCard(loaded) = %+v, %v", card, err)
+	}
+	if card, err := ParseIDCard("11010519491231002X"); err != nil || card.Area != "朝阳" {
+		t.Errorf("ParseIDCard(replaced) = %+v, %v", card, err)
+	}
+	// 加载只补充内置数据
+	RegisterRegions(map[string]string{"119998": "测试区"})
+	if _, err := ParseIDCard("440304200002290014"); err != nil {
+		t.Errorf("ParseIDCard(builtin) failed. %v", err)
+	}
+	if err := LoadRegions(strings.NewReader("119997 测试区\n11010 东城区\n")); err == nil {
+		t.Error("LoadRegions() invalid line should fail")
+	}
+	if _, err := ParseIDCard("119997850101001"); err == nil {
+		t.Error("LoadRegions() invalid input should not be loaded")
+	}
+}
+
+func TestIDCardRule(t *testing.T) {
+	rules := []ValidationItem{{Key: "idCard", Name: "身份证号码", Rules: MustParseRules("idcard")}}
+	cfg := &Config{Clock: ClockFunc(func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC) })}
+
+	if _, err := ValidationAll(testParams(map[string]string{"idCard": "440304200002290014"}), rules, WithConfig(cfg)); err != nil {
+		t.Errorf("ValidationAll() failed. %v", err)
+	}
+	_, _, err := Validation(testParams(map[string]string{"idCard": "440304200002300014"}), rules, WithConfig(cfg))
+	if fe, ok := err.(*FieldError); !ok || fe.Error() != "身份证号码 不是有效的身份证号码" || fe.Err == nil {
+		t.Errorf("Validation() = %v", err)
+	}
+	if !ValifyIdCardCode("110101850101001") || ValifyIdCardCode("11010120030101a012") {
+		t.Error("ValifyIdCardCode() should use ParseIDCard")
+	}
+}
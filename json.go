@@ -0,0 +1,262 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/13 14:18
+ * @Desc: JSON 参数来源，ValidationItem.Key 支持 address.city、items[0].sku、items[*].sku 形式的路径
+ */
+
+const (
+	JSONPathInvalid = "JSON 路径 %s 不合法"
+)
+
+// 展开通配路径的参数来源
+type PathSource interface {
+	Source
+	// 将含有 [*] 的路径展开为实际存在的路径，如 items[*].sku => items[0].sku、items[1].sku
+	Expand(pattern string) []string
+}
+
+// JSON 参数来源
+type JSONSource struct {
+	doc interface{}
+}
+
+// 使用已解析的 JSON 文档创建参数来源，doc 为 json.Unmarshal 得到的 map[string]interface{} 或 []interface{}
+func NewJSONSource(doc interface{}) *JSONSource {
+	return &JSONSource{doc: doc}
+}
+
+// 解析 JSON 创建参数来源，数字保留原始格式
+func ParseJSON(body []byte) (*JSONSource, error) {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return NewJSONSource(doc), nil
+}
+
+// 路径对应的值，数组以 DefaultSeparator 连接，对象返回 JSON 字符串，不存在或为 null 时返回空字符串
+func (s *JSONSource) Get(path string) string {
+	v, ok := s.lookup(path)
+	if !ok {
+		return ""
+	}
+	if list, ok := v.([]interface{}); ok {
+		return strings.Join(jsonStrings(list), DefaultSeparator)
+	}
+	return jsonString(v)
+}
+
+// 路径对应的值及路径是否存在，值为 null 时返回 "", true
+func (s *JSONSource) Lookup(path string) (string, bool) {
+	if _, ok := s.lookup(path); !ok {
+		return "", false
+	}
+	return s.Get(path), true
+}
+
+// 路径对应数组的全部值，不是数组时返回单个值
+func (s *JSONSource) Values(path string) []string {
+	v, ok := s.lookup(path)
+	if !ok || v == nil {
+		return nil
+	}
+	if list, ok := v.([]interface{}); ok {
+		return jsonStrings(list)
+	}
+	return []string{jsonString(v)}
+}
+
+// 展开含有通配符的路径，父级参数不存在或类型不符时视为只有一个不存在的元素，以便 required 等规则报告错误
+func (s *JSONSource) Expand(pattern string) []string {
+	segments, err := parseJSONPath(pattern)
+	if err != nil {
+		return nil
+	}
+
+	var paths []string
+	var walk func(v interface{}, path string, segments []jsonPathSegment)
+	walk = func(v interface{}, path string, segments []jsonPathSegment) {
+		if len(segments) == 0 {
+			paths = append(paths, path)
+			return
+		}
+		seg := segments[0]
+		switch {
+		case seg.key != "":
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				paths = append(paths, missingJSONPath(path, segments))
+				return
+			}
+			child, ok := obj[seg.key]
+			if !ok || child == nil {
+				// 字段不存在时仍返回路径，以便 required 等规则报告错误
+				paths = append(paths, missingJSONPath(joinJSONPath(path, seg.key), segments[1:]))
+				return
+			}
+			walk(child, joinJSONPath(path, seg.key), segments[1:])
+		case seg.wildcard:
+			list, ok := v.([]interface{})
+			if !ok {
+				paths = append(paths, missingJSONPath(path, segments))
+				return
+			}
+			for i, child := range list {
+				walk(child, path+"["+strconv.Itoa(i)+"]", segments[1:])
+			}
+		default:
+			list, ok := v.([]interface{})
+			if !ok || seg.index >= len(list) {
+				paths = append(paths, missingJSONPath(path, segments))
+				return
+			}
+			walk(list[seg.index], path+"["+strconv.Itoa(seg.index)+"]", segments[1:])
+		}
+	}
+	walk(s.doc, "", segments)
+	return paths
+}
+
+// 不存在的参数的路径，通配符按第一个元素展开
+func missingJSONPath(path string, segments []jsonPathSegment) string {
+	for _, seg := range segments {
+		switch {
+		case seg.key != "":
+			path = joinJSONPath(path, seg.key)
+		case seg.wildcard:
+			path += "[0]"
+		default:
+			path += "[" + strconv.Itoa(seg.index) + "]"
+		}
+	}
+	return path
+}
+
+// 按路径查找值
+func (s *JSONSource) lookup(path string) (interface{}, bool) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, false
+	}
+
+	v := s.doc
+	for _, seg := range segments {
+		if seg.wildcard {
+			return nil, false
+		}
+		if seg.key != "" {
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if v, ok = obj[seg.key]; !ok {
+				return nil, false
+			}
+			continue
+		}
+		list, ok := v.([]interface{})
+		if !ok || seg.index >= len(list) {
+			return nil, false
+		}
+		v = list[seg.index]
+	}
+	return v, true
+}
+
+// 路径片段，key 为对象字段，否则为数组下标
+type jsonPathSegment struct {
+	key      string
+	index    int
+	wildcard bool
+}
+
+// 解析路径，如 items[0].sku => items、[0]、sku
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	var segments []jsonPathSegment
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			return nil, fmt.Errorf(JSONPathInvalid, path)
+		}
+		name := part
+		if i := strings.Index(part, "["); i >= 0 {
+			name = part[:i]
+		}
+		if name != "" {
+			segments = append(segments, jsonPathSegment{key: name})
+		}
+
+		rest := part[len(name):]
+		for rest != "" {
+			end := strings.Index(rest, "]")
+			if rest[0] != '[' || end < 0 {
+				return nil, fmt.Errorf(JSONPathInvalid, path)
+			}
+			index := rest[1:end]
+			if index == "*" {
+				segments = append(segments, jsonPathSegment{wildcard: true})
+			} else {
+				n, err := strconv.Atoi(index)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf(JSONPathInvalid, path)
+				}
+				segments = append(segments, jsonPathSegment{index: n})
+			}
+			rest = rest[end+1:]
+		}
+	}
+	return segments, nil
+}
+
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	if key == "" {
+		return path
+	}
+	return path + "." + key
+}
+
+// 是否为含有通配符的路径
+func isWildcardPath(key string) bool {
+	return strings.Contains(key, "[*]")
+}
+
+// JSON 值转换为字符串
+func jsonString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func jsonStrings(list []interface{}) []string {
+	values := make([]string, 0, len(list))
+	for _, v := range list {
+		values = append(values, jsonString(v))
+	}
+	return values
+}
@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/13 17:30
+ * @Desc:
+ */
+
+const testJSONBody = `{
+	"orgId": 12,
+	"price": 12.50,
+	"isSync": true,
+	"address": {"city": "上海", "zip": null},
+	"tags": ["a", "b"],
+	"items": [
+		{"sku": "A001", "qty": 1},
+		{"sku": "", "qty": 100},
+		{"qty": 2}
+	]
+}`
+
+func TestJSONSource(t *testing.T) {
+	src, err := ParseJSON([]byte(testJSONBody))
+	if err != nil {
+		t.Fatalf("ParseJSON() failed. %v", err)
+	}
+
+	tests := []struct {
+		path   string
+		expect string
+	}{
+		{"orgId", "12"},
+		{"price", "12.50"},
+		{"isSync", "true"},
+		{"address.city", "上海"},
+		{"address.zip", ""},
+		{"address.street", ""},
+		{"tags", "a,b"},
+		{"tags[1]", "b"},
+		{"items[0].sku", "A001"},
+		{"items[1].qty", "100"},
+		{"items[5].qty", ""},
+		{"items..qty", ""},
+	}
+	for _, test := range tests {
+		if v := src.Get(test.path); v != test.expect {
+			t.Errorf("JSONSource.Get(%s) = %q, want %q", test.path, v, test.expect)
+		}
+	}
+
+	if values := src.Values("tags"); !reflect.DeepEqual(values, []string{"a", "b"}) {
+		t.Errorf("JSONSource.Values(tags) = %v", values)
+	}
+	expect := []string{"items[0].sku", "items[1].sku", "items[2].sku"}
+	if paths := src.Expand("items[*].sku"); !reflect.DeepEqual(paths, expect) {
+		t.Errorf("JSONSource.Expand() = %v, want %v", paths, expect)
+	}
+}
+
+func TestValidateJSON(t *testing.T) {
+	src, _ := ParseJSON([]byte(testJSONBody))
+	schema := MustCompile([]ValidationItem{
+		{Key: "orgId", Name: "组织机构id", Rules: []ValidationRule{{Rule: "required"}, {Rule: "integer"}}},
+		{Key: "address.city", Name: "城市", Rules: []ValidationRule{{Rule: "required"}}},
+		{Key: "tags", Name: "标签", Multiple: true, Rules: []ValidationRule{{Rule: "in", Data: []string{"a", "b"}}}},
+		{Key: "items[*].sku", Name: "商品编号", Rules: []ValidationRule{{Rule: "required"}}},
+		{Key: "items[*].qty", Name: "数量", Rules: []ValidationRule{{Rule: "between", Data: []int{1, 99}}}},
+	})
+
+	data, err := schema.ValidateAllSource(src)
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("ValidateAllSource() returned %v", err)
+	}
+	keys := make([]string, len(errs))
+	for i, item := range errs {
+		keys[i] = item.Key
+	}
+	if expect := []string{"items[1].sku", "items[2].sku", "items[1].qty"}; !reflect.DeepEqual(keys, expect) {
+		t.Errorf("ValidateAllSource() error keys = %v, want %v", keys, expect)
+	}
+	if data["items[0].sku"] != "A001" || data["address.city"] != "上海" {
+		t.Errorf("ValidateAllSource() unexpected data: %v", data)
+	}
+}
+
+// 通配路径的父级参数不存在或类型不符时按一个不存在的元素验证
+func TestValidateJSONMissingParent(t *testing.T) {
+	schema := MustCompile([]ValidationItem{
+		{Key: "items[*].sku", Name: "商品编号", Rules: []ValidationRule{{Rule: "required"}}},
+	})
+	tests := []struct {
+		body   string
+		expect string
+	}{
+		{`{}`, "items[0].sku"},
+		{`{"items": null}`, "items[0].sku"},
+		{`{"items": []}`, ""},
+		{`{"items": "oops"}`, "items[0].sku"},
+		{`{"items": ["oops"]}`, "items[0].sku"},
+		{`{"items": [{"sku": "a"}, 3]}`, "items[1].sku"},
+		{`{"items": [{"sku": "a"}]}`, ""},
+	}
+	for _, test := range tests {
+		src, _ := ParseJSON([]byte(test.body))
+		_, key, err := schema.ValidateSource(src)
+		if (err != nil) != (test.expect != "") || key != test.expect {
+			t.Errorf("ValidateSource(%s) = %q %v, want %q", test.body, key, err, test.expect)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package validator
+
+import (
+	"fmt"
+	"strconv"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/10 11:40
+ * @Desc: 多值参数的内置规则，对全部值整体验证
+ */
+
+const ConfigCountNotInteger = "多值参数的数量限制必须为整数"
+
+var listCompilers = map[string]ruleCompiler{
+	"required":     compileListRequired,
+	"in":           compileListIn,
+	"arrayInArray": compileListArrayInArray,
+	"distinct":     compileListDistinct,
+	"between":      compileListCount("between"),
+	"min":          compileListCount("min"),
+	"max":          compileListCount("max"),
+}
+
+// 至少有一个非空值
+func compileListRequired(_ interface{}) (RuleFunc, error) {
+	return func(c *RuleContext) error {
+		if len(c.Values) == 0 {
+			return newFieldError(c.Item, c.Index, c.Value, MsgRequired, ValidateValCanNotEmpty)
+		}
+		return nil
+	}, nil
+}
+
+// 每个值都在允许的数组内
+func compileListIn(data interface{}) (RuleFunc, error) {
+	if err := checkStringList(data); err != nil {
+		return nil, err
+	}
+	allowed := map[string]bool{}
+	for _, v := range data.([]string) {
+		allowed[v] = true
+	}
+
+	return func(c *RuleContext) error {
+		for _, v := range c.Values {
+			if !allowed[v] {
+				return newFieldError(c.Item, c.Index, v, MsgIn, ValidateValNotExists)
+			}
+		}
+		return nil
+	}, nil
+}
+
+// 每个值都在允许的数组内，多值参数不再按分隔符拆分
+func compileListArrayInArray(data interface{}) (RuleFunc, error) {
+	if err := checkArrayInArray(data); err != nil {
+		return nil, err
+	}
+	list := data.([]interface{})[1]
+
+	allowed := map[string]bool{}
+	_, isInt := list.([]int)
+	switch l := list.(type) {
+	case []string:
+		for _, v := range l {
+			allowed[v] = true
+		}
+	case []int:
+		for _, v := range l {
+			allowed[strconv.Itoa(v)] = true
+		}
+	}
+
+	return func(c *RuleContext) error {
+		for _, v := range c.Values {
+			if isInt {
+				if n, err := strconv.Atoi(v); err == nil {
+					v = strconv.Itoa(n)
+				}
+			}
+			if !allowed[v] {
+				return newFieldError(c.Item, c.Index, c.Value, MsgArrayInArray, ValidateValArrayNotInArray, list)
+			}
+		}
+		return nil
+	}, nil
+}
+
+// 没有重复的值，多值参数的分隔符可以省略
+func compileListDistinct(data interface{}) (RuleFunc, error) {
+	if data != nil {
+		if err := checkSeparator(data); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(c *RuleContext) error {
+		seen := make(map[string]bool, len(c.Values))
+		for _, v := range c.Values {
+			if seen[v] {
+				return newFieldError(c.Item, c.Index, c.Value, MsgDistinct, ValidateValMustDistinct, v)
+			}
+			seen[v] = true
+		}
+		return nil
+	}, nil
+}
+
+// 值的数量限制
+func compileListCount(rule string) ruleCompiler {
+	return func(data interface{}) (RuleFunc, error) {
+		size, err := parseSizeRule(rule, data)
+		if err != nil {
+			return nil, err
+		}
+		if size.kind == "float" {
+			return nil, fmt.Errorf(ConfigCountNotInteger)
+		}
+
+		var key, format string
+		args := size.intArgs()
+		switch rule {
+		case "between":
+			key, format = MsgBetweenCount, ValidateValNotBetweenCount
+		case "min":
+			key, format = MsgMinCount, ValidateValNotMinCount
+		case "max":
+			key, format = MsgMaxCount, ValidateValNotMaxCount
+		}
+
+		return func(c *RuleContext) error {
+			if len(c.Values) == 0 || size.inInts(len(c.Values)) {
+				return nil
+			}
+			return newFieldError(c.Item, c.Index, c.Value, key, format, args...)
+		}, nil
+	}
+}
@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/18 11:20
+ * @Desc:
+ */
+
+var testNestedItems = []ValidationItem{
+	{Key: "address", Name: "地址", Children: []ValidationItem{
+		{Key: "city", Name: "城市", Rules: []ValidationRule{{Rule: "required"}}},
+		{Key: "zip", Name: "邮编", Rules: []ValidationRule{{Rule: "integer"}}},
+	}},
+	{Key: "items", Name: "商品", Rules: []ValidationRule{{Rule: "required"}}, Each: &ValidationItem{
+		Name: "商品",
+		Children: []ValidationItem{
+			{Key: "sku", Name: "商品编号", Rules: []ValidationRule{{Rule: "required"}}},
+			{Key: "qty", Name: "数量", Rules: []ValidationRule{{Rule: "between", Data: []int{1, 99}}}},
+		},
+	}},
+	{Key: "tags", Name: "标签", Each: &ValidationItem{Name: "标签", Rules: []ValidationRule{{Rule: "max", Data: "3"}}}},
+}
+
+func TestValidateNested(t *testing.T) {
+	src, _ := ParseJSON([]byte(`{
+		"address": {"city": "", "zip": "20000a"},
+		"items": [{"sku": "A001", "qty": 1}, {"sku": "", "qty": 100}],
+		"tags": ["go", "json"]
+	}`))
+
+	data, err := MustCompile(testNestedItems).ValidateAllSource(src)
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("ValidateAllSource() returned %v", err)
+	}
+
+	if len(errs) != 3 || errs[0].Key != "address" || errs[1].Key != "items" || errs[2].Key != "tags" {
+		t.Fatalf("ValidateAllSource() unexpected top level errors: %+v", errs)
+	}
+	if len(errs[1].Children) != 1 || errs[1].Children[0].Key != "items[1]" || len(errs[1].Children[0].Children) != 2 {
+		t.Errorf("ValidateAllSource() unexpected items errors: %+v", errs[1])
+	}
+
+	keys := []string{}
+	for _, item := range errs.Flatten() {
+		keys = append(keys, item.Key)
+	}
+	expect := []string{"address.city", "address.zip", "items[1].sku", "items[1].qty", "tags[1]"}
+	if !reflect.DeepEqual(keys, expect) {
+		t.Errorf("ValidationErrors.Flatten() keys = %v, want %v", keys, expect)
+	}
+	if data["items[0].sku"] != "A001" || data["tags[0]"] != "go" {
+		t.Errorf("ValidateAllSource() unexpected data: %v", data)
+	}
+}
+
+func TestValidateNestedParams(t *testing.T) {
+	schema := MustCompile(testNestedItems)
+	params := map[string]string{"address.city": "上海", "items": "x", "items[0].sku": "A001", "tags": "go,rust"}
+
+	_, key, err := schema.Validate(testParams(params))
+	if err == nil || key != "tags[1]" {
+		t.Errorf("Validate() key = %s, err: %v", key, err)
+	}
+
+	if _, err := Compile([]ValidationItem{{Key: "items", Each: &ValidationItem{Rules: []ValidationRule{{Rule: "unknown"}}}}}); err == nil ||
+		err.(*ConfigError).Key != "items[*]" {
+		t.Errorf("Compile() should report nested config error, got %v", err)
+	}
+}
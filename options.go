@@ -0,0 +1,60 @@
+package validator
+
+import "context"
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/14 14:30
+ * @Desc: 验证选项
+ */
+
+// 验证选项，用于单次验证调用
+type Option func(*options)
+
+type options struct {
+	locale      string          // 错误信息语言
+	translator  Translator      // 错误信息翻译器
+	ctx         context.Context // 规则使用的 context
+	concurrency int             // 并发执行顶层验证项的 goroutine 数量
+	config      *Config         // 时区及时钟配置
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{translator: defaultTranslator}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.ctx == nil {
+		o.ctx = context.Background()
+	}
+	if o.config == nil {
+		o.config = DefaultConfig()
+	}
+	return o
+}
+
+// 设置错误信息语言，如 en-US，为空时使用规则自带的默认信息
+func WithLocale(locale string) Option {
+	return func(o *options) {
+		o.locale = locale
+	}
+}
+
+// 设置错误信息翻译器，默认使用内置的翻译目录
+func WithTranslator(t Translator) Option {
+	return func(o *options) {
+		o.translator = t
+	}
+}
+
+// 按选项翻译错误信息
+func (o *options) translate(fe *FieldError) *FieldError {
+	if o.locale == "" || o.translator == nil {
+		return fe
+	}
+	if msg, ok := o.translator.Translate(o.locale, fe); ok {
+		fe.Message = msg
+	}
+	return fe
+}
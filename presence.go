@@ -0,0 +1,65 @@
+package validator
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/1 10:20
+ * @Desc: 区分参数未提交与提交空值的规则，参数来源需实现 LookupSource，否则以参数值是否为空判断是否提交
+ *
+ * {Rule: "present"}    必须提交，可以为空
+ * {Rule: "filled"}     提交时不能为空，未提交时不检查
+ * {Rule: "nullable"}   为空时跳过剩余规则，参数仍出现在验证结果中
+ * {Rule: "sometimes"}  未提交时跳过剩余规则，且不出现在验证结果中，适用于部分更新
+ *
+ * nullable、sometimes 只影响排在其后的规则，通常作为第一条规则
+ */
+
+const (
+	ValidateValMustPresent = "%s 必须提交"
+	ValidateValMustFilled  = "%s 提交时不能为空"
+)
+
+const (
+	MsgPresent = "present"
+	MsgFilled  = "filled"
+)
+
+func init() {
+	presences := map[string]RuleFunc{
+		"present":   present,
+		"filled":    filled,
+		"nullable":  nullable,
+		"sometimes": sometimes,
+	}
+	for name, fn := range presences {
+		ruleRegistry[name] = &ruleEntry{fn: fn, list: true}
+	}
+}
+
+func present(c *RuleContext) error {
+	if !c.Present {
+		return newFieldError(c.Item, c.Index, c.Value, MsgPresent, ValidateValMustPresent)
+	}
+	return nil
+}
+
+func filled(c *RuleContext) error {
+	if c.Present && c.Value == "" {
+		return newFieldError(c.Item, c.Index, c.Value, MsgFilled, ValidateValMustFilled)
+	}
+	return nil
+}
+
+func nullable(c *RuleContext) error {
+	if c.Value == "" {
+		return SkipRules
+	}
+	return nil
+}
+
+func sometimes(c *RuleContext) error {
+	if !c.Present {
+		return ExcludeField
+	}
+	return nil
+}
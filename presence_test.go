@@ -0,0 +1,71 @@
+package validator
+
+import "testing"
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/6/1 14:46
+ * @Desc:
+ */
+
+func TestPresenceRules(t *testing.T) {
+	tests := []struct {
+		rules  []ValidationRule
+		params Values
+		expect bool
+	}{
+		{[]ValidationRule{{Rule: "present"}}, Values{"v": {""}}, true},
+		{[]ValidationRule{{Rule: "present"}}, Values{}, false},
+		{[]ValidationRule{{Rule: "filled"}}, Values{}, true},
+		{[]ValidationRule{{Rule: "filled"}}, Values{"v": {""}}, false},
+		{[]ValidationRule{{Rule: "filled"}}, Values{"v": {"1"}}, true},
+		{[]ValidationRule{{Rule: "nullable"}, {Rule: "required"}}, Values{"v": {""}}, true},
+		{[]ValidationRule{{Rule: "nullable"}, {Rule: "integer"}}, Values{"v": {"a"}}, false},
+		{[]ValidationRule{{Rule: "sometimes"}, {Rule: "required"}}, Values{}, true},
+		{[]ValidationRule{{Rule: "sometimes"}, {Rule: "required"}}, Values{"v": {""}}, false},
+	}
+
+	for _, test := range tests {
+		rules := []ValidationItem{{Key: "v", Name: "v", Rules: test.rules}}
+		if _, err := MustCompile(rules).ValidateAllSource(test.params); (err == nil) != test.expect {
+			t.Errorf("ValidateAllSource(%v, %v) = %v", test.rules, test.params, err)
+		}
+	}
+}
+
+// 部分更新：未提交的参数不出现在验证结果中，提交空值的参数可以清空
+func TestPresencePatch(t *testing.T) {
+	schema := MustCompile([]ValidationItem{
+		{Key: "nickname", Name: "昵称", Rules: []ValidationRule{{Rule: "sometimes"}, {Rule: "filled"}, {Rule: "max", Data: 20}}},
+		{Key: "remark", Name: "备注", Rules: []ValidationRule{{Rule: "sometimes"}, {Rule: "nullable"}, {Rule: "max", Data: 100}}},
+	})
+
+	data, err := schema.ValidateAllSource(Values{"remark": {""}})
+	if err != nil {
+		t.Fatalf("ValidateAllSource() failed. %v", err)
+	}
+	if _, ok := data["nickname"]; ok {
+		t.Errorf("ValidateAllSource() nickname should be absent: %v", data)
+	}
+	if v, ok := data["remark"]; !ok || v != "" {
+		t.Errorf("ValidateAllSource() remark should be cleared: %v", data)
+	}
+
+	if _, err := schema.ValidateAllSource(Values{"nickname": {""}}); err == nil {
+		t.Error("ValidateAllSource() empty nickname should fail")
+	}
+
+	src, _ := ParseJSON([]byte(`{"remark": null}`))
+	data, err = schema.ValidateAllSource(src)
+	if _, ok := data["remark"]; err != nil || !ok {
+		t.Errorf("ValidateAllSource(json) = %v, %v", data, err)
+	}
+
+	lookup := LookupFunc(func(key string) (string, bool) {
+		return "", key == "nickname"
+	})
+	if _, err := schema.ValidateAllSource(lookup); err == nil {
+		t.Error("ValidateAllSource(LookupFunc) empty nickname should fail")
+	}
+}
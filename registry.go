@@ -0,0 +1,272 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/19 10:12
+ * @Desc: 验证规则注册表
+ */
+
+const (
+	RegisterRuleNameEmpty = "验证规则名称不能为空"
+	RegisterRuleFuncNil   = "验证规则 %s 的方法不能为空"
+	RegisterRuleExists    = "验证规则 %s 已存在"
+	RegisterRuleNotExists = "验证规则 %s 不存在"
+)
+
+// 规则执行上下文
+type RuleContext struct {
+	Item    *ValidationItem // 当前验证项
+	Index   int             // 当前规则在 Item.Rules 中的下标
+	Value   string          // 当前参数值，多值参数逐个验证时为其中一个值，规则修改后传给后续规则
+	Values  []string        // 多值参数的全部值，规则修改后传给后续规则
+	Present bool            // 参数是否提交，参数来源未实现 LookupSource 时为参数值是否为空
+
+	ctx    context.Context
+	config *Config
+	scope  *scope
+}
+
+var (
+	// 规则返回 SkipRules 时跳过该参数剩余的规则，参数仍出现在验证结果中
+	SkipRules = errors.New("skip remaining rules")
+	// 规则返回 ExcludeField 时跳过该参数剩余的规则，且参数不出现在验证结果中
+	ExcludeField = errors.New("exclude field")
+)
+
+// 当前执行的规则
+func (c *RuleContext) Rule() ValidationRule {
+	return c.Item.Rules[c.Index]
+}
+
+// 同级参数的值，嵌套验证项中 key 相对于父级参数，顶层验证项中为完整参数键
+// 同级参数有验证项时返回经过其过滤器处理后的值
+func (c *RuleContext) Param(key string) string {
+	if c.scope == nil {
+		return ""
+	}
+	path := joinJSONPath(c.scope.parent, key)
+	if v := c.sibling(key); v != nil {
+		return v.filterParam(c, path)
+	}
+	return c.scope.src.Get(path)
+}
+
+// 同级参数的名称，没有对应的验证项时返回 key
+func (c *RuleContext) FieldName(key string) string {
+	if v := c.sibling(key); v != nil && v.item.Name != "" {
+		return v.item.Name
+	}
+	return key
+}
+
+// 同级参数的验证项
+func (c *RuleContext) sibling(key string) *compiledItem {
+	if c.scope != nil {
+		for _, v := range c.scope.siblings {
+			if v.item.Key == key {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+// 当前验证项是否为同级参数 key
+func (c *RuleContext) isKey(key string) bool {
+	parent := ""
+	if c.scope != nil {
+		parent = c.scope.parent
+	}
+	return c.Item.Key == joinJSONPath(parent, key)
+}
+
+// 当前规则的扩展数据
+func (c *RuleContext) Data() interface{} {
+	return c.Item.Rules[c.Index].Data
+}
+
+// 构造当前规则的验证错误，format 的第一个占位符为参数名称，翻译时以规则名称为翻译键
+func (c *RuleContext) Fail(format string, args ...interface{}) error {
+	return newFieldError(c.Item, c.Index, c.Value, "", format, args...)
+}
+
+// 验证规则方法，验证通过返回 nil
+type RuleFunc func(c *RuleContext) error
+
+// 验证规则配置检查方法，检查 ValidationRule.Data 是否合法
+type RuleChecker func(data interface{}) error
+
+type ruleEntry struct {
+	fn          RuleFunc
+	check       RuleChecker
+	compile     ruleCompiler
+	list        bool         // fn 是否直接处理多值参数的全部值
+	listCompile ruleCompiler // 多值参数的预编译方法
+	filter      bool         // 是否为过滤器，同级参数引用时同样执行
+	custom      bool         // 规则方法由使用方提供，编译时不使用默认值执行
+}
+
+var (
+	ruleMu       sync.RWMutex
+	ruleRegistry = map[string]*ruleEntry{}
+)
+
+func init() {
+	builtins := map[string]func(*ValidationItem, int, string) error{
+		"required":     ValidationRequired,
+		"in":           ValidationIn,
+		"bool":         ValidationBool,
+		"integer":      ValidationInteger,
+		"between":      ValidationBetween,
+		"min":          ValidationMin,
+		"max":          ValidationMax,
+		"arrayInArray": ValidationArrayInArray,
+		"filterChar":   ValidationFilterChar,
+		"regexp":       ValidationRegexp,
+		"func":         ValidationFunc,
+		"distinct":     ValidationDistinct,
+	}
+	for name, fn := range builtins {
+		ruleRegistry[name] = &ruleEntry{
+			fn:          wrapRule(fn),
+			check:       builtinCheckers[name],
+			compile:     builtinCompilers[name],
+			listCompile: listCompilers[name],
+			custom:      name == "func",
+		}
+	}
+}
+
+// 将 ValidationXxx 形式的规则方法转换为 RuleFunc
+func wrapRule(fn func(*ValidationItem, int, string) error) RuleFunc {
+	return func(c *RuleContext) error {
+		return fn(c.Item, c.Index, c.Value)
+	}
+}
+
+// 注册自定义验证规则，规则名称已存在时返回错误
+func RegisterRule(name string, fn RuleFunc) error {
+	if name == "" {
+		return fmt.Errorf(RegisterRuleNameEmpty)
+	}
+	if fn == nil {
+		return fmt.Errorf(RegisterRuleFuncNil, name)
+	}
+
+	ruleMu.Lock()
+	defer ruleMu.Unlock()
+
+	if _, ok := ruleRegistry[name]; ok {
+		return fmt.Errorf(RegisterRuleExists, name)
+	}
+	ruleRegistry[name] = &ruleEntry{fn: fn, custom: true}
+	return nil
+}
+
+// 注册处理多值参数的验证规则，多值参数的全部值通过 RuleContext.Values 一次传入，
+// 普通规则则对多值参数的每个值分别执行
+func RegisterListRule(name string, fn RuleFunc) error {
+	if err := RegisterRule(name, fn); err != nil {
+		return err
+	}
+
+	ruleMu.Lock()
+	defer ruleMu.Unlock()
+
+	ruleRegistry[name].list = true
+	return nil
+}
+
+// 注册验证规则，规则名称已存在时覆盖，可用于替换内置规则
+// 覆盖时保留原有的配置检查方法，内置规则的预编译不再生效
+func OverrideRule(name string, fn RuleFunc) error {
+	if name == "" {
+		return fmt.Errorf(RegisterRuleNameEmpty)
+	}
+	if fn == nil {
+		return fmt.Errorf(RegisterRuleFuncNil, name)
+	}
+
+	ruleMu.Lock()
+	defer ruleMu.Unlock()
+
+	if entry, ok := ruleRegistry[name]; ok {
+		check := entry.check
+		if check == nil && entry.compile != nil {
+			check = checkByCompile(entry.compile)
+		}
+		ruleRegistry[name] = &ruleEntry{fn: fn, check: check, custom: true}
+		return nil
+	}
+	ruleRegistry[name] = &ruleEntry{fn: fn, custom: true}
+	return nil
+}
+
+// 为已注册的验证规则设置配置检查方法
+func RegisterRuleChecker(name string, check RuleChecker) error {
+	ruleMu.Lock()
+	defer ruleMu.Unlock()
+
+	entry, ok := ruleRegistry[name]
+	if !ok {
+		return fmt.Errorf(RegisterRuleNotExists, name)
+	}
+	ruleRegistry[name] = &ruleEntry{
+		fn:          entry.fn,
+		check:       check,
+		compile:     entry.compile,
+		list:        entry.list,
+		listCompile: entry.listCompile,
+		filter:      entry.filter,
+		custom:      entry.custom,
+	}
+	return nil
+}
+
+// 查找验证规则
+func lookupRule(name string) (*ruleEntry, bool) {
+	ruleMu.RLock()
+	defer ruleMu.RUnlock()
+
+	entry, ok := ruleRegistry[name]
+	return entry, ok
+}
+
+// 编译单条规则，检查参数后优先使用预编译方法，否则直接使用规则方法，多值参数优先使用多值预编译方法
+// 返回的 bool 表示规则是否一次处理多值参数的全部值
+func compileRule(item *ValidationItem, rule ValidationRule) (RuleFunc, bool, error) {
+	entry, ok := lookupRule(rule.Rule)
+	if !ok {
+		return nil, false, fmt.Errorf(ConfigRuleNotExists)
+	}
+	if item.Multiple && entry.listCompile != nil {
+		fn, err := entry.listCompile(rule.Data)
+		return fn, true, err
+	}
+	if entry.check != nil {
+		if err := entry.check(rule.Data); err != nil {
+			return nil, false, err
+		}
+	}
+	if entry.compile != nil {
+		fn, err := entry.compile(rule.Data)
+		return fn, item.Multiple && entry.list, err
+	}
+	return entry.fn, item.Multiple && entry.list, nil
+}
+
+// 使用预编译方法检查规则参数
+func checkByCompile(compile ruleCompiler) RuleChecker {
+	return func(data interface{}) error {
+		_, err := compile(data)
+		return err
+	}
+}
@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/19 11:35
+ * @Desc:
+ */
+
+// 测试结束后恢复规则注册表中的规则，以便重复运行测试
+func restoreRule(t *testing.T, name string) {
+	ruleMu.RLock()
+	original, ok := ruleRegistry[name]
+	ruleMu.RUnlock()
+
+	t.Cleanup(func() {
+		ruleMu.Lock()
+		defer ruleMu.Unlock()
+		if ok {
+			ruleRegistry[name] = original
+		} else {
+			delete(ruleRegistry, name)
+		}
+	})
+}
+
+func TestRegisterRule(t *testing.T) {
+	restoreRule(t, "testUuid")
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	err := RegisterRule("testUuid", func(c *RuleContext) error {
+		if c.Value != "" && !uuid.MatchString(c.Value) {
+			return c.Fail("%s 不是有效的 uuid")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterRule() failed. %v", err)
+	}
+	if err := RegisterRule("testUuid", func(c *RuleContext) error { return nil }); err == nil {
+		t.Error("RegisterRule() should reject duplicate rule names.")
+	}
+	if err := RegisterRule("required", func(c *RuleContext) error { return nil }); err == nil {
+		t.Error("RegisterRule() should reject built-in rule names.")
+	}
+
+	rules := []ValidationItem{{Key: "id", Name: "编号", Rules: []ValidationRule{{Rule: "testUuid"}}}}
+	tests := []struct {
+		in     string
+		expect bool
+	}{
+		{"0f8fad5b-d9cb-469f-a165-70867728950e", true},
+		{"0f8fad5b", false},
+		{"", true},
+	}
+	for _, test := range tests {
+		_, _, err := Validation(testParams(map[string]string{"id": test.in}), rules)
+		if (err == nil) != test.expect {
+			t.Errorf("Validation(%q) with testUuid = %v", test.in, err)
+		}
+		if fe, ok := err.(*FieldError); ok && (fe.Rule != "testUuid" || fe.Error() != "编号 不是有效的 uuid") {
+			t.Errorf("Validation(%q) unexpected FieldError: %+v", test.in, fe)
+		}
+	}
+}
+
+func TestOverrideRule(t *testing.T) {
+	restoreRule(t, "filterChar")
+
+	err := OverrideRule("filterChar", func(c *RuleContext) error {
+		return c.Fail("%s 已被覆盖")
+	})
+	if err != nil {
+		t.Fatalf("OverrideRule() failed. %v", err)
+	}
+
+	rules := []ValidationItem{{Key: "keywords", Name: "关键词", Rules: []ValidationRule{{Rule: "filterChar", Data: []string{"%"}}}}}
+	_, _, err = Validation(testParams(map[string]string{"keywords": "go"}), rules)
+	if err == nil || err.Error() != "关键词 已被覆盖" {
+		t.Errorf("Validation() with overridden rule = %v", err)
+	}
+}
+
+// 规则返回包装后的 SkipRules 及 ExcludeField 时与直接返回相同
+func TestWrappedSentinel(t *testing.T) {
+	restoreRule(t, "testSkip")
+	restoreRule(t, "testExclude")
+	_ = RegisterRule("testSkip", func(c *RuleContext) error {
+		return fmt.Errorf("skip %s: %w", c.Item.Key, SkipRules)
+	})
+	_ = RegisterRule("testExclude", func(c *RuleContext) error {
+		return fmt.Errorf("exclude %s: %w", c.Item.Key, ExcludeField)
+	})
+
+	schema := MustCompile([]ValidationItem{
+		{Key: "a", Name: "a", Rules: []ValidationRule{{Rule: "testSkip"}, {Rule: "required"}}},
+		{Key: "b", Name: "b", Rules: []ValidationRule{{Rule: "testExclude"}, {Rule: "required"}}},
+	})
+	data, err := schema.ValidateAll(testParams(map[string]string{}))
+	if err != nil {
+		t.Fatalf("ValidateAll() failed. %v", err)
+	}
+	if _, ok := data["a"]; !ok {
+		t.Errorf("ValidateAll() should keep skipped field: %v", data)
+	}
+	if _, ok := data["b"]; ok {
+		t.Errorf("ValidateAll() should exclude field: %v", data)
+	}
+}
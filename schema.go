@@ -0,0 +1,447 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/22 14:05
+ * @Desc: 预编译的验证规则集合
+ */
+
+// 预编译的验证项集合，编译后不可修改，可在多个 goroutine 中并发使用
+type Schema struct {
+	items []*compiledItem
+}
+
+// 预编译的验证项
+type compiledItem struct {
+	item     ValidationItem
+	rules    []RuleFunc
+	list     []bool          // 规则是否一次处理多值参数的全部值
+	filter   []bool          // 规则是否为过滤器
+	custom   []bool          // 规则方法是否由使用方提供
+	children []*compiledItem // 对象参数的子验证项
+	each     *compiledItem   // 数组参数元素的验证项
+}
+
+// 编译验证项，检查规则是否存在、规则参数是否合法，并预先解析规则参数
+func Compile(rules []ValidationItem) (*Schema, error) {
+	items, err := compileItems(rules, "")
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{items: items}, nil
+}
+
+// 递归编译验证项，prefix 为父级参数键，用于配置错误提示
+func compileItems(rules []ValidationItem, prefix string) ([]*compiledItem, error) {
+	items := make([]*compiledItem, 0, len(rules))
+
+	for _, v := range rules {
+		path := joinJSONPath(prefix, v.Key)
+		item := &compiledItem{item: v}
+		item.item.Rules = append([]ValidationRule(nil), v.Rules...)
+		item.item.Children = nil
+		item.item.Each = nil
+		for _, vI := range v.Rules {
+			fn, list, err := compileRule(&item.item, vI)
+			if err != nil {
+				return nil, &ConfigError{Key: path, Rule: vI.Rule, Err: err}
+			}
+			entry, _ := lookupRule(vI.Rule)
+			item.rules = append(item.rules, fn)
+			item.list = append(item.list, list)
+			item.filter = append(item.filter, entry.filter)
+			item.custom = append(item.custom, entry.custom)
+		}
+
+		if err := item.checkDefault(path); err != nil {
+			return nil, err
+		}
+
+		children, err := compileItems(v.Children, path)
+		if err != nil {
+			return nil, err
+		}
+		item.children = children
+
+		if v.Each != nil {
+			each, err := compileItems([]ValidationItem{*v.Each}, path+"[*]")
+			if err != nil {
+				return nil, err
+			}
+			item.each = each[0]
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// 编译验证项，出错时 panic，适用于包级变量初始化
+func MustCompile(rules []ValidationItem) *Schema {
+	schema, err := Compile(rules)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// 参数验证，遇到第一个错误即返回
+func (s *Schema) Validate(params func(string) string, opts ...Option) (map[string]string, string, error) {
+	return s.ValidateSource(ParamsFunc(params), opts...)
+}
+
+// 参数验证，执行全部验证项并返回所有错误
+func (s *Schema) ValidateAll(params func(string) string, opts ...Option) (map[string]string, error) {
+	return s.ValidateAllSource(ParamsFunc(params), opts...)
+}
+
+// 从参数来源读取参数并验证，遇到第一个错误即返回
+// 规则执行出错时返回 *RuleError，context 取消或超时时返回 context 的错误
+func (s *Schema) ValidateSource(src Source, opts ...Option) (map[string]string, string, error) {
+	r, key, err := s.validateSource(newOptions(opts), src)
+	if err != nil {
+		return nil, key, err
+	}
+	return r.data, "", nil
+}
+
+// 从参数来源读取参数并验证，执行全部验证项并返回所有错误
+// 返回的错误不是 ValidationErrors 时为规则执行错误，此时验证已中止
+func (s *Schema) ValidateAllSource(src Source, opts ...Option) (map[string]string, error) {
+	r, errs := s.run(newOptions(opts), src, false)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if len(errs) > 0 {
+		return r.data, errs
+	}
+	return r.data, nil
+}
+
+// 从参数来源读取参数并验证，执行全部验证项并返回所有错误
+// 与 ValidateAllSource 相同，多值参数保留验证后的全部值，不以分隔符连接
+func (s *Schema) ValidateAllValues(src Source, opts ...Option) (Values, error) {
+	r, errs := s.run(newOptions(opts), src, false)
+	if r.err != nil {
+		return nil, r.err
+	}
+	values := make(Values, len(r.data))
+	for k, v := range r.data {
+		if list, ok := r.values[k]; ok {
+			values[k] = list
+		} else {
+			values[k] = []string{v}
+		}
+	}
+	if len(errs) > 0 {
+		return values, errs
+	}
+	return values, nil
+}
+
+// 遇到第一个错误即停止，返回执行状态及第一个错误对应的参数键
+func (s *Schema) validateSource(o *options, src Source) (*runner, string, error) {
+	r, errs := s.run(o, src, true)
+	if r.err != nil {
+		return nil, ruleErrorKey(r.err), r.err
+	}
+	if len(errs) > 0 {
+		first := errs.Flatten()[0]
+		return nil, first.Key, first.Errors[0]
+	}
+	return r, "", nil
+}
+
+// 执行全部验证项，failFast 为 true 时遇到第一个错误即停止，规则执行出错时中止并将执行错误记录在返回的执行状态中
+func (s *Schema) run(o *options, src Source, failFast bool) (*runner, ValidationErrors) {
+	if o.concurrency > 1 {
+		return s.runConcurrent(o, src, failFast)
+	}
+	r := newRunner(o, src, failFast)
+	errs := r.items(s.items, "")
+	return r, errs
+}
+
+// 执行错误对应的参数键，context 的错误没有参数键
+func ruleErrorKey(err error) string {
+	if re, ok := err.(*RuleError); ok {
+		return re.Key
+	}
+	return ""
+}
+
+// 单次验证的执行状态
+type runner struct {
+	o        *options
+	src      Source
+	failFast bool
+	stop     bool                // failFast 时已出现错误，或规则执行出错
+	err      error               // 规则执行错误
+	data     map[string]string   // 验证通过的参数，对象及数组参数只保存其中的末级参数
+	values   map[string][]string // 验证通过的多值参数的全部值
+}
+
+func newRunner(o *options, src Source, failFast bool) *runner {
+	return &runner{o: o, src: src, failFast: failFast, data: map[string]string{}, values: map[string][]string{}}
+}
+
+// 执行一组验证项，prefix 为父级参数键
+func (r *runner) items(items []*compiledItem, prefix string) ValidationErrors {
+	var errs ValidationErrors
+
+	sc := &scope{src: r.src, parent: prefix, siblings: items}
+	for _, v := range items {
+		for _, path := range r.expand(joinJSONPath(prefix, v.item.Key)) {
+			if itemErrs := r.item(sc, v, path, nil); itemErrs != nil {
+				errs = append(errs, *itemErrs)
+			}
+			if r.stop {
+				return errs
+			}
+		}
+	}
+
+	return errs
+}
+
+// 执行单个验证项及其子验证项，elem 不为空时为数组元素的值
+func (r *runner) item(sc *scope, v *compiledItem, path string, elem *string) *ItemErrors {
+	if err := r.o.ctx.Err(); err != nil {
+		r.abort(err)
+		return nil
+	}
+
+	item := v.item
+	item.Key = path
+
+	var val string
+	var values []string
+	present := true
+	if elem != nil {
+		val = *elem
+	} else {
+		val, values = readItem(r.src, &item)
+		present = lookupItem(r.src, path)
+	}
+	// 提交空值时不使用默认值，参数来源无法区分时以参数值是否为空判断
+	if !present && item.Default != "" {
+		val, values = item.defaultValue()
+	}
+
+	itemErrs, val, values, excluded := v.validate(r, sc, &item, val, values, present)
+	if excluded || r.err != nil {
+		return nil
+	}
+	result := ItemErrors{Key: path, Errors: itemErrs}
+	if len(result.Errors) > 0 && r.failFast {
+		r.stop = true
+		return &result
+	}
+
+	if len(v.children) > 0 {
+		result.Children = r.items(v.children, path)
+	}
+	if v.each != nil && !r.stop {
+		result.Children = append(result.Children, r.elements(v.each, path, values)...)
+	}
+
+	if len(v.children) == 0 && v.each == nil && len(result.Errors) == 0 {
+		r.data[path] = val
+		if item.Multiple {
+			r.values[path] = values
+		}
+	}
+	if len(result.Errors) == 0 && len(result.Children) == 0 {
+		return nil
+	}
+	return &result
+}
+
+// 中止验证
+func (r *runner) abort(err error) {
+	r.err = err
+	r.stop = true
+}
+
+// 执行数组参数每个元素的验证项，元素参数键为 path[i]
+func (r *runner) elements(each *compiledItem, path string, values []string) ValidationErrors {
+	var errs ValidationErrors
+	add := func(itemErrs *ItemErrors) bool {
+		if itemErrs != nil {
+			errs = append(errs, *itemErrs)
+		}
+		return !r.stop
+	}
+
+	// 数组元素之间不是同级参数，同级参数只有元素本身
+	sc := &scope{src: r.src, parent: path, siblings: []*compiledItem{each}}
+
+	// 支持路径的参数来源直接按路径读取元素，否则使用多值参数的全部值
+	if ps, ok := r.src.(PathSource); ok {
+		for _, elemPath := range ps.Expand(path + "[*]") {
+			if !add(r.item(sc, each, elemPath, nil)) {
+				break
+			}
+		}
+		return errs
+	}
+
+	if values == nil {
+		item := ValidationItem{Key: path, Multiple: true}
+		_, values = readItem(r.src, &item)
+	}
+	for i := range values {
+		if !add(r.item(sc, each, path+"["+strconv.Itoa(i)+"]", &values[i])) {
+			break
+		}
+	}
+	return errs
+}
+
+// 展开含有通配符的参数键
+func (r *runner) expand(path string) []string {
+	ps, ok := r.src.(PathSource)
+	if !ok || !isWildcardPath(path) {
+		return []string{path}
+	}
+	return ps.Expand(path)
+}
+
+// 按顺序执行验证项的规则，present 为参数是否提交，failFast 时遇到第一个错误即停止，返回经过滤器处理后的参数值
+// 多值参数中不处理全部值的规则，对每个值分别执行，执行后为空的值被去除
+// 规则返回 SkipRules 时跳过剩余规则，返回 ExcludeField 时同时将参数从验证结果中排除，返回执行错误时中止验证
+func (v *compiledItem) validate(r *runner, sc *scope, item *ValidationItem, val string, values []string, present bool) ([]error, string, []string, bool) {
+	var errs []error
+
+	for vIk, fn := range v.rules {
+		c := &RuleContext{Item: item, Index: vIk, Value: val, Values: values, Present: present, ctx: r.o.ctx, config: r.o.config, scope: sc}
+		var err error
+		if v.item.Multiple && !v.list[vIk] {
+			for i := range values {
+				c.Value = values[i]
+				err = fn(c)
+				values[i] = c.Value
+				if err != nil {
+					break
+				}
+			}
+			// 过滤器处理后为空的值与未提交的值相同
+			values = nonEmpty(values)
+			c.Values = values
+		} else {
+			err = fn(c)
+			val, values = c.Value, c.Values
+		}
+		if v.item.Multiple {
+			val = strings.Join(values, itemSeparator(item))
+		}
+
+		switch {
+		case err == nil:
+			continue
+		case errors.Is(err, SkipRules):
+			return errs, val, values, false
+		case errors.Is(err, ExcludeField):
+			return nil, val, values, true
+		}
+		if re, ok := asRuleError(c, err); ok {
+			r.abort(re)
+			return nil, val, values, false
+		}
+		errs = append(errs, r.o.translate(toFieldError(item, vIk, c.Value, err)))
+		if r.failFast {
+			break
+		}
+	}
+
+	return errs, val, values, false
+}
+
+// 使用验证项自身的内置规则检查默认值，依赖其他参数或当前时间的规则不检查，检查结果不随编译时间变化
+// 自定义规则可能访问外部资源，遇到自定义规则时停止检查，其后的规则依赖其处理结果同样不检查
+// 检查时使用已取消的 context
+func (v *compiledItem) checkDefault(path string) error {
+	if v.item.Default == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	item := v.item
+	item.Key = path
+	val, values := item.defaultValue()
+	for vIk, fn := range v.rules {
+		if v.custom[vIk] {
+			return nil
+		}
+		src, clock := &probeSource{}, &probeClock{}
+		config := &Config{Location: DefaultConfig().Location, Clock: clock}
+		c := &RuleContext{Item: &item, Index: vIk, Value: val, Values: values, Present: true, ctx: ctx, config: config, scope: &scope{src: src}}
+		var err error
+		if item.Multiple && !v.list[vIk] {
+			for i := range values {
+				c.Value = values[i]
+				err = fn(c)
+				values[i] = c.Value
+				if err != nil {
+					break
+				}
+			}
+			// 过滤器处理后为空的值与未提交的值相同
+			values = nonEmpty(values)
+			c.Values = values
+		} else {
+			err = fn(c)
+			val, values = c.Value, c.Values
+		}
+
+		if errors.Is(err, SkipRules) || errors.Is(err, ExcludeField) {
+			return nil
+		}
+		if _, ok := asRuleError(c, err); ok {
+			return nil
+		}
+		if err != nil && !src.used && !clock.used {
+			return &ConfigError{Key: path, Rule: item.Rules[vIk].Rule, Err: fmt.Errorf(ConfigDefaultInvalid, item.Default, err)}
+		}
+	}
+	return nil
+}
+
+// 记录规则是否读取了其他参数的参数来源，用于检查默认值
+type probeSource struct {
+	used bool
+}
+
+func (s *probeSource) Get(string) string {
+	s.used = true
+	return ""
+}
+
+// 记录规则是否读取了当前时间的时钟，用于检查默认值
+type probeClock struct {
+	used bool
+}
+
+func (c *probeClock) Now() time.Time {
+	c.used = true
+	return time.Now()
+}
+
+// 验证项所在的层级，用于读取同级参数
+type scope struct {
+	src      Source
+	parent   string          // 父级参数键
+	siblings []*compiledItem // 同级验证项
+}
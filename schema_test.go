@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"sync"
+	"testing"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/22 17:10
+ * @Desc:
+ */
+
+func TestCompiledRules(t *testing.T) {
+	tests := []struct {
+		rule   ValidationRule
+		in     string
+		expect bool
+	}{
+		{ValidationRule{Rule: "between", Data: []int{1, 10}}, "10", true},
+		{ValidationRule{Rule: "between", Data: []int{1, 10}}, "11", false},
+		{ValidationRule{Rule: "between", Data: []float64{0.5, 1.5}}, "1.2", true},
+		{ValidationRule{Rule: "between", Data: []float64{0.5, 1.5}}, "a", false},
+		{ValidationRule{Rule: "between", Data: []string{"2", "3"}}, "中文字", true},
+		{ValidationRule{Rule: "between", Data: []string{"2", "3"}}, "中文字符", false},
+		{ValidationRule{Rule: "min", Data: 1}, "0", false},
+		{ValidationRule{Rule: "min", Data: 1.5}, "1.5", true},
+		{ValidationRule{Rule: "min", Data: "3"}, "ab", false},
+		{ValidationRule{Rule: "max", Data: 100}, "100", true},
+		{ValidationRule{Rule: "max", Data: "3"}, "abcd", false},
+		{ValidationRule{Rule: "arrayInArray", Data: []interface{}{",", []int{1, 2, 3}}}, "1,3", true},
+		{ValidationRule{Rule: "arrayInArray", Data: []interface{}{",", []int{1, 2, 3}}}, "1,4", false},
+		{ValidationRule{Rule: "arrayInArray", Data: []interface{}{",", []string{"id", "name"}}}, "id,age", false},
+		{ValidationRule{Rule: "regexp", Data: ValidationMobileData()}, "13501691436", true},
+		{ValidationRule{Rule: "regexp", Data: ValidationMobileData()}, "12909090909", false},
+	}
+
+	for _, test := range tests {
+		rules := []ValidationItem{{Key: "k", Name: "n", Rules: []ValidationRule{test.rule}}}
+		schema := MustCompile(rules)
+		_, _, err := schema.Validate(testParams(map[string]string{"k": test.in}))
+		if (err == nil) != test.expect {
+			t.Errorf("Schema.Validate(%s %v, %q) = %v", test.rule.Rule, test.rule.Data, test.in, err)
+		}
+
+		// 预编译规则与 ValidationXxx 方法的结果应保持一致
+		item := rules[0]
+		entry, _ := lookupRule(test.rule.Rule)
+		legacyErr := entry.fn(&RuleContext{Item: &item, Index: 0, Value: test.in})
+		if (legacyErr == nil) != test.expect || (err != nil && err.Error() != legacyErr.Error()) {
+			t.Errorf("%s(%q) = %v, compiled = %v", test.rule.Rule, test.in, legacyErr, err)
+		}
+	}
+}
+
+func TestSchemaConcurrent(t *testing.T) {
+	schema := MustCompile([]ValidationItem{
+		{Key: "pageSize", Name: "每页记录条数", Rules: []ValidationRule{{Rule: "between", Data: []int{1, 100}}}},
+		{Key: "email", Name: "邮箱", Rules: []ValidationRule{{Rule: "regexp", Data: ValidationEmailData()}}},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			params := map[string]string{"pageSize": "10", "email": "booldesign@163.com"}
+			if i%2 == 1 {
+				params["email"] = "booldesign"
+			}
+			_, err := schema.ValidateAll(testParams(params), WithLocale("en-US"))
+			if (err == nil) != (i%2 == 0) {
+				t.Errorf("Schema.ValidateAll() #%d = %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
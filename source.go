@@ -0,0 +1,110 @@
+package validator
+
+import "strings"
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/10 10:26
+ * @Desc: 参数来源
+ */
+
+// 参数来源
+type Source interface {
+	Get(key string) string
+}
+
+// 支持同一参数多个值的参数来源
+type MultiSource interface {
+	Source
+	Values(key string) []string
+}
+
+// 能够区分参数未提交与提交空值的参数来源
+type LookupSource interface {
+	Source
+	// 参数值及参数是否提交，提交空值时返回 "", true
+	Lookup(key string) (string, bool)
+}
+
+// 将 func(string) string 形式的参数方法转换为 Source
+type ParamsFunc func(string) string
+
+func (f ParamsFunc) Get(key string) string {
+	return f(key)
+}
+
+// 将 func(string) (string, bool) 形式的参数方法转换为 LookupSource
+type LookupFunc func(string) (string, bool)
+
+func (f LookupFunc) Get(key string) string {
+	val, _ := f(key)
+	return val
+}
+
+func (f LookupFunc) Lookup(key string) (string, bool) {
+	return f(key)
+}
+
+// 多值参数来源，可由 url.Values 直接转换
+type Values map[string][]string
+
+// 同名参数多次提交时以 DefaultSeparator 连接
+func (v Values) Get(key string) string {
+	return strings.Join(v[key], DefaultSeparator)
+}
+
+func (v Values) Values(key string) []string {
+	return v[key]
+}
+
+func (v Values) Lookup(key string) (string, bool) {
+	list, ok := v[key]
+	return strings.Join(list, DefaultSeparator), ok
+}
+
+// 参数是否提交，参数来源未实现 LookupSource 时以参数值是否为空判断
+func lookupItem(src Source, key string) bool {
+	if ls, ok := src.(LookupSource); ok {
+		_, present := ls.Lookup(key)
+		return present
+	}
+	return src.Get(key) != ""
+}
+
+// 读取验证项的值，多值参数同时返回全部非空值
+func readItem(src Source, item *ValidationItem) (string, []string) {
+	if !item.Multiple {
+		return src.Get(item.Key), nil
+	}
+
+	sep := itemSeparator(item)
+	var values []string
+	if ms, ok := src.(MultiSource); ok {
+		values = ms.Values(item.Key)
+	} else if val := src.Get(item.Key); val != "" {
+		values = strings.Split(val, sep)
+	}
+
+	list := nonEmpty(values)
+	return strings.Join(list, sep), list
+}
+
+// 去除空值
+func nonEmpty(values []string) []string {
+	list := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// 验证项的默认值，多值参数按分隔符拆分
+func (item *ValidationItem) defaultValue() (string, []string) {
+	if !item.Multiple {
+		return item.Default, nil
+	}
+	return readItem(ParamsFunc(func(string) string { return item.Default }), item)
+}
@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"net/url"
+	"testing"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/5/10 16:02
+ * @Desc:
+ */
+
+func TestValidateMultiSource(t *testing.T) {
+	schema := MustCompile([]ValidationItem{
+		{Key: "tag", Name: "标签", Multiple: true, Rules: []ValidationRule{
+			{Rule: "required"},
+			{Rule: "in", Data: []string{"a", "b", "c,d"}},
+			{Rule: "distinct"},
+			{Rule: "max", Data: 2},
+		}},
+		{Key: "ids", Name: "编号", Multiple: true, Rules: []ValidationRule{
+			{Rule: "integer"},
+			{Rule: "arrayInArray", Data: []interface{}{",", []int{1, 2, 3}}},
+		}},
+	})
+
+	tests := []struct {
+		query  string
+		expect string
+	}{
+		{"tag=a&tag=b&ids=1&ids=3", ""},
+		{"tag=c,d&ids=1", ""},
+		{"tag=&ids=1", "tag"},
+		{"tag=a&tag=a", "tag"},
+		{"tag=a&tag=b&tag=c,d", "tag"},
+		{"tag=a&tag=x", "tag"},
+		{"tag=a&ids=1&ids=x", "ids"},
+		{"tag=a&ids=1&ids=4", "ids"},
+	}
+
+	for _, test := range tests {
+		values, _ := url.ParseQuery(test.query)
+		data, key, err := schema.ValidateSource(Values(values))
+		if key != test.expect {
+			t.Errorf("ValidateSource(%s) key = %s, want %s, err: %v", test.query, key, test.expect, err)
+		}
+		if test.query == tests[0].query && (data["tag"] != "a,b" || data["ids"] != "1,3") {
+			t.Errorf("ValidateSource(%s) unexpected data: %v", test.query, data)
+		}
+	}
+}
+
+func TestValidateMultiParams(t *testing.T) {
+	schema := MustCompile([]ValidationItem{
+		{Key: "ids", Name: "编号", Multiple: true, Rules: []ValidationRule{
+			{Rule: "distinct", Data: "|"},
+			{Rule: "between", Data: []int{1, 3}},
+		}},
+	})
+
+	if _, _, err := schema.Validate(testParams(map[string]string{"ids": "1|2|3"})); err != nil {
+		t.Errorf("Validate() failed. %v", err)
+	}
+	_, _, err := schema.Validate(testParams(map[string]string{"ids": "1|2|3|4"}))
+	if err == nil || err.(*FieldError).MsgKey != MsgBetweenCount {
+		t.Errorf("Validate() should check element count, got %v", err)
+	}
+	if _, err := Compile([]ValidationItem{{Key: "ids", Multiple: true, Rules: []ValidationRule{{Rule: "min", Data: 1.5}}}}); err == nil {
+		t.Error("Compile() should reject float count.")
+	}
+}
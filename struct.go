@@ -0,0 +1,382 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/26 10:18
+ * @Desc: 根据结构体标签生成验证项
+ *
+ * type ListRequest struct {
+ *     OrgId    int    `form:"orgId" name:"组织机构id" validate:"required,integer,min=1"`
+ *     Status   string `form:"status" name:"状态" validate:"in=DELETED ENABLED DISABLED"`
+ *     PageSize int    `form:"pageSize" name:"每页记录条数" validate:"between=-1 100"`
+ *     Mobile   string `form:"mobile" name:"手机号" validate:"required,regexp=mobile"`
+ * }
+ *
+ * 参数键依次取 form、json 标签，都没有时使用字段名；参数名称取 name 标签，没有时使用参数键；默认值取 default 标签
+ * validate 中多个规则以逗号分隔，规则参数写在等号之后，多个参数以空格分隔，标签为 - 时忽略该字段
+ * 切片字段（[]byte 除外）作为多值参数验证
+ */
+
+const (
+	TagKey      = "form"
+	TagJSONKey  = "json"
+	TagName     = "name"
+	TagValidate = "validate"
+	TagDefault  = "default"
+)
+
+const (
+	StructTypeNotAllow  = "仅支持结构体，不支持 %s"
+	StructParamRequired = "缺少规则参数"
+	StructParamNotFound = "未找到名为 %s 的规则参数"
+)
+
+// func 规则在标签及规则字符串中可引用的自定义验证方法
+var structFuncRules = map[string]func() ValidationFuncRule{
+	"idArray":   ValidationIdArrayData,
+	"token":     ValidationTokenArrayData,
+	"idCard":    ValidationIdCardCodeData,
+	"username":  ValidationUsernameData,
+	"realname":  ValidationRealnameData,
+	"password":  ValidationPasswordData,
+	"objectId":  ValidationObjectId,
+	"objectIds": ValidationObjectIds,
+}
+
+// func 规则在标签及规则字符串中可引用的依赖时区及当前时间的方法，使用单次验证的配置
+var structConfigFuncRules = map[string]ValidationConfigFuncRule{
+	"birthday": (*Config).BirthdayData,
+	"date":     (*Config).DateData,
+	"startAt":  (*Config).StartAtData,
+}
+
+// regexp 规则在标签及规则字符串中可引用的正则
+var structRegexpRules = map[string]func() ValidationRegexpRule{
+	"mobile": ValidationMobileData,
+	"email":  ValidationEmailData,
+}
+
+var structSchemas sync.Map // reflect.Type => *Schema
+
+// 根据结构体标签生成验证项，v 为结构体或结构体指针
+func StructItems(v interface{}) ([]ValidationItem, error) {
+	t := structType(v)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf(StructTypeNotAllow, reflect.TypeOf(v))
+	}
+	return structItems(t)
+}
+
+// 根据结构体标签生成并编译验证项，同一类型只编译一次
+func StructSchema(v interface{}) (*Schema, error) {
+	t := structType(v)
+	if schema, ok := structSchemas.Load(t); ok {
+		return schema.(*Schema), nil
+	}
+
+	items, err := StructItems(v)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := Compile(items)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := structSchemas.LoadOrStore(t, schema)
+	return actual.(*Schema), nil
+}
+
+// 按结构体标签进行参数验证，遇到第一个错误即返回
+func ValidationStruct(params func(string) string, v interface{}, opts ...Option) (map[string]string, string, error) {
+	schema, err := StructSchema(v)
+	if err != nil {
+		if ce, ok := err.(*ConfigError); ok {
+			return nil, ce.Key, err
+		}
+		return nil, "", err
+	}
+	return schema.Validate(params, opts...)
+}
+
+// 去除指针后的类型
+func structType(v interface{}) reflect.Type {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// 结构体中对应参数的字段
+type structField struct {
+	reflect.StructField
+	index []int // 字段在顶层结构体中的索引路径
+}
+
+// 结构体中对应参数的字段，验证与绑定共用
+// 没有 validate 及 form 标签的匿名结构体或结构体指针字段展开，validate 标签为 - 的字段及未导出的字段跳过
+func structFields(t reflect.Type, index []int) []structField {
+	var fields []structField
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get(TagValidate)
+		if tag == "-" {
+			continue
+		}
+		path := append(append([]int(nil), index...), i)
+
+		// 匿名结构体字段展开
+		if f.Anonymous && tag == "" && f.Tag.Get(TagKey) == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, structFields(ft, path)...)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		fields = append(fields, structField{StructField: f, index: path})
+	}
+
+	return fields
+}
+
+// 按索引路径取得字段，途经的空结构体指针自动创建，无法创建时返回 false
+func structFieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func structItems(t reflect.Type) ([]ValidationItem, error) {
+	var items []ValidationItem
+
+	for _, field := range structFields(t, nil) {
+		f := field.StructField
+		tag := f.Tag.Get(TagValidate)
+
+		item := ValidationItem{Key: structFieldKey(f)}
+		if item.Key == "" {
+			continue
+		}
+		item.Name = f.Tag.Get(TagName)
+		if item.Name == "" {
+			item.Name = item.Key
+		}
+
+		rules, err := parseStructTag(f.Type, tag)
+		if err != nil {
+			err.(*ConfigError).Key = item.Key
+			return nil, err
+		}
+		item.Rules = rules
+		item.Multiple = f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() != reflect.Uint8
+		item.Default = f.Tag.Get(TagDefault)
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// 字段对应的参数键
+func structFieldKey(f reflect.StructField) string {
+	for _, name := range []string{TagKey, TagJSONKey} {
+		if key := strings.Split(f.Tag.Get(name), ",")[0]; key != "" {
+			if key == "-" {
+				return ""
+			}
+			return key
+		}
+	}
+	return f.Name
+}
+
+// 解析 validate 标签
+func parseStructTag(t reflect.Type, tag string) ([]ValidationRule, error) {
+	if tag == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(tag, ",")
+	kind := structSizeKind(t, parts)
+
+	rules := make([]ValidationRule, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, param := part, ""
+		hasParam := false
+		if i := strings.Index(part, "="); i >= 0 {
+			name, param, hasParam = part[:i], part[i+1:], true
+		}
+
+		ints := t.Kind() == reflect.Slice && structSizeKind(t.Elem(), nil) == "int"
+		data, err := ruleData(name, param, strings.Fields(param), hasParam, kind, ints)
+		if err != nil {
+			return nil, &ConfigError{Rule: name, Err: err}
+		}
+		rules = append(rules, ValidationRule{Rule: name, Data: data})
+	}
+	return rules, nil
+}
+
+// between、min、max 参数的类型，规则中含有 integer 或字段为整数时按整数比较，
+// 字段为浮点数时按浮点数比较，其他情况按字符串长度比较
+func structSizeKind(t reflect.Type, parts []string) string {
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "integer" {
+			return "int"
+		}
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	}
+	return "string"
+}
+
+// 将标签或规则字符串中的规则参数转换为 ValidationRule.Data，fields 为拆分后的参数
+// kind 为 between、min、max 参数的类型，ints 为 arrayInArray 是否按整数比较
+func ruleData(name, param string, fields []string, hasParam bool, kind string, ints bool) (interface{}, error) {
+	switch name {
+	case "required", "bool", "integer":
+		return nil, nil
+	case "in", "filterChar", "required_if", "required_unless", "required_with", "required_with_all",
+		"required_without", "exclude_if", "within":
+		if len(fields) == 0 {
+			return nil, fmt.Errorf(StructParamRequired)
+		}
+		return fields, nil
+	case "between", "min", "max":
+		if len(fields) == 0 {
+			return nil, fmt.Errorf(StructParamRequired)
+		}
+		return sizeRuleData(name, fields, kind)
+	case "distinct":
+		if !hasParam {
+			return ",", nil
+		}
+		return param, nil
+	case "arrayInArray":
+		if len(fields) == 0 {
+			return nil, fmt.Errorf(StructParamRequired)
+		}
+		if ints {
+			list := make([]int, len(fields))
+			for i, v := range fields {
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					return nil, fmt.Errorf(ConfigDataNotInteger, v)
+				}
+				list[i] = n
+			}
+			return []interface{}{",", list}, nil
+		}
+		return []interface{}{",", fields}, nil
+	case "func":
+		if rule, ok := structConfigFuncRules[param]; ok {
+			return rule, nil
+		}
+		fn, ok := structFuncRules[param]
+		if !ok {
+			return nil, fmt.Errorf(StructParamNotFound, param)
+		}
+		return fn(), nil
+	case "regexp":
+		fn, ok := structRegexpRules[param]
+		if !ok {
+			return nil, fmt.Errorf(StructParamNotFound, param)
+		}
+		return fn(), nil
+	case "age":
+		if len(fields) == 2 {
+			return sizeRuleData("between", fields, "int")
+		}
+		return sizeRuleData("min", fields, "int")
+	case "date_range":
+		return parseDateRangeFields(fields)
+	case "eqfield", "same", "different", "gtfield", "gtefield", "ltfield", "ltefield":
+		if len(fields) == 1 {
+			return fields[0], nil
+		}
+		return fields, nil
+	}
+
+	// 自定义规则，参数原样传入
+	if !hasParam {
+		return nil, nil
+	}
+	return param, nil
+}
+
+func sizeRuleData(name string, fields []string, kind string) (interface{}, error) {
+	if name == "between" && len(fields) != 2 {
+		return nil, fmt.Errorf(ConfigDataLength, 2)
+	}
+	if name != "between" && len(fields) != 1 {
+		return nil, fmt.Errorf(ConfigDataLength, 1)
+	}
+
+	switch kind {
+	case "int":
+		list := make([]int, len(fields))
+		for i, v := range fields {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf(ConfigDataNotInteger, v)
+			}
+			list[i] = n
+		}
+		if name == "between" {
+			return list, nil
+		}
+		return list[0], nil
+	case "float":
+		list := make([]float64, len(fields))
+		for i, v := range fields {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = n
+		}
+		if name == "between" {
+			return list, nil
+		}
+		return list[0], nil
+	}
+
+	if name == "between" {
+		return fields, nil
+	}
+	return fields[0], nil
+}
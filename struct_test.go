@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/26 15:40
+ * @Desc:
+ */
+
+type testPage struct {
+	PageNum  int `form:"pageNum" name:"页号" validate:"min=1,max=100"`
+	PageSize int `form:"pageSize" name:"每页记录条数" validate:"between=-1 100"`
+}
+
+type testListRequest struct {
+	testPage
+	OrgId    string   `form:"orgId" name:"组织机构id" validate:"required,integer,min=1"`
+	Status   string   `json:"status" name:"状态" validate:"in=DELETED ENABLED DISABLED"`
+	Keywords string   `form:"keywords" validate:"max=10,filterChar=% _"`
+	Ids      []int    `form:"ids" name:"编号" validate:"arrayInArray=1 2 3,distinct"`
+	Price    float64  `form:"price" name:"价格" validate:"between=0.5 99.5"`
+	Mobile   string   `form:"mobile" name:"手机号" validate:"regexp=mobile"`
+	Username string   `form:"username" name:"用户名" validate:"func=username"`
+	Ignored  string   `form:"ignored" validate:"-"`
+	Tags     []string `form:"-"`
+	internal string
+}
+
+func TestStructItems(t *testing.T) {
+	items, err := StructItems(&testListRequest{})
+	if err != nil {
+		t.Fatalf("StructItems() failed. %v", err)
+	}
+
+	expect := map[string][]ValidationRule{
+		"pageNum":  {{Rule: "min", Data: 1}, {Rule: "max", Data: 100}},
+		"pageSize": {{Rule: "between", Data: []int{-1, 100}}},
+		"orgId":    {{Rule: "required"}, {Rule: "integer"}, {Rule: "min", Data: 1}},
+		"status":   {{Rule: "in", Data: []string{"DELETED", "ENABLED", "DISABLED"}}},
+		"keywords": {{Rule: "max", Data: "10"}, {Rule: "filterChar", Data: []string{"%", "_"}}},
+		"ids":      {{Rule: "arrayInArray", Data: []interface{}{",", []int{1, 2, 3}}}, {Rule: "distinct", Data: ","}},
+		"price":    {{Rule: "between", Data: []float64{0.5, 99.5}}},
+	}
+	if len(items) != 9 {
+		t.Fatalf("StructItems() returned %d items, want 9", len(items))
+	}
+	for _, item := range items {
+		rules, ok := expect[item.Key]
+		if ok && !reflect.DeepEqual(item.Rules, rules) {
+			t.Errorf("StructItems() %s = %v, want %v", item.Key, item.Rules, rules)
+		}
+	}
+	if items[4].Name != "keywords" {
+		t.Errorf("StructItems() should use key as default name, got %s", items[4].Name)
+	}
+}
+
+func TestStructItemsInvalidTag(t *testing.T) {
+	tests := []interface{}{
+		struct {
+			A int `validate:"between=1"`
+		}{},
+		struct {
+			A string `validate:"regexp=phone"`
+		}{},
+		struct {
+			A int `validate:"min=a"`
+		}{},
+		"not a struct",
+	}
+
+	for _, test := range tests {
+		if _, err := StructSchema(test); err == nil {
+			t.Errorf("StructSchema(%T) should fail.", test)
+		}
+	}
+}
+
+func TestValidationStruct(t *testing.T) {
+	params := map[string]string{"pageNum": "1", "pageSize": "10", "orgId": "1", "status": "ENABLED", "ids": "1,2"}
+	data, _, err := ValidationStruct(testParams(params), testListRequest{})
+	if err != nil || data["orgId"] != "1" {
+		t.Errorf("ValidationStruct() failed. %v", err)
+	}
+
+	params["ids"] = "1,1"
+	if _, key, err := ValidationStruct(testParams(params), &testListRequest{}); err == nil || key != "ids" {
+		t.Errorf("ValidationStruct() should fail on ids, got key: %s, err: %v", key, err)
+	}
+
+	s1, _ := StructSchema(&testListRequest{})
+	s2, _ := StructSchema(testListRequest{})
+	if s1 != s2 {
+		t.Error("StructSchema() should cache schema per type.")
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-	"unicode/utf8"
 )
 
 /**
@@ -34,6 +33,32 @@ const (
 	ValidateValArrayNotInArray  = "%s 不能含有 %v 以外的值"
 	ValidateValExistsFilterChar = "%s 不允许包含 %v"
 	ValidateValMustDistinct     = "%s 含有重复的值 [%s]"
+	ValidateValNotBetweenCount  = "%s 必须包含 %d - %d 项"
+	ValidateValNotMinCount      = "%s 至少包含 %d 项"
+	ValidateValNotMaxCount      = "%s 最多包含 %d 项"
+)
+
+// 错误信息的翻译键，与翻译目录中的条目对应
+const (
+	MsgRequired     = "required"
+	MsgIn           = "in"
+	MsgBool         = "bool"
+	MsgInteger      = "integer"
+	MsgBetweenInt   = "between.int"
+	MsgBetweenFloat = "between.float"
+	MsgBetweenStr   = "between.string"
+	MsgMinInt       = "min.int"
+	MsgMinFloat     = "min.float"
+	MsgMinStr       = "min.string"
+	MsgMaxInt       = "max.int"
+	MsgMaxFloat     = "max.float"
+	MsgMaxStr       = "max.string"
+	MsgArrayInArray = "arrayInArray"
+	MsgFilterChar   = "filterChar"
+	MsgDistinct     = "distinct"
+	MsgBetweenCount = "between.count"
+	MsgMinCount     = "min.count"
+	MsgMaxCount     = "max.count"
 )
 
 // 验证规则，多个验证规则组合成一个验证项
@@ -44,59 +69,38 @@ type ValidationRule struct {
 
 // 验证项，用于验证某个参数
 type ValidationItem struct {
-	Key   string           // 参数键
-	Name  string           // 参数名称
-	Rules []ValidationRule // 规则
+	Key      string           // 参数键
+	Name     string           // 参数名称
+	Rules    []ValidationRule // 规则
+	Multiple bool             // 是否为多值参数，如 ?tag=a&tag=b
+	Default  string           // 参数未提交时使用的默认值，多值参数以分隔符连接多个默认值
+	Children []ValidationItem // 对象参数的子验证项，子项参数键为 Key.子项Key
+	Each     *ValidationItem  // 数组参数每个元素的验证项，元素参数键为 Key[i]，Each.Key 不使用
 }
 
-// 参数验证
-func Validation(params func(string) string, rules []ValidationItem) (map[string]string, string, error) {
-	var err error
-	data := map[string]string{}
-
-	for _, v := range rules {
-		val := params(v.Key)
-		for vIk, vI := range v.Rules {
-			switch vI.Rule {
-			case "required":
-				err = ValidationRequired(&v, vIk, val)
-			case "in":
-				err = ValidationIn(&v, vIk, val)
-			case "bool":
-				err = ValidationBool(&v, vIk, val)
-			case "integer":
-				err = ValidationInteger(&v, vIk, val)
-			case "between":
-				err = ValidationBetween(&v, vIk, val)
-			case "min":
-				err = ValidationMin(&v, vIk, val)
-			case "max":
-				err = ValidationMax(&v, vIk, val)
-			case "arrayInArray":
-				err = ValidationArrayInArray(&v, vIk, val)
-			case "filterChar":
-				err = ValidationFilterChar(&v, vIk, val)
-			case "regexp":
-				err = ValidationRegexp(&v, vIk, val)
-			case "func":
-				err = ValidationFunc(&v, vIk, val)
-			case "distinct":
-				err = ValidationDistinct(&v, vIk, val)
-			}
-			if err != nil {
-				return nil, v.Key, err
-			}
-		}
-		data[v.Key] = val
+// 参数验证，遇到第一个错误即返回
+// 每次调用都会重新编译验证项，高频调用时应使用 Compile 得到的 Schema
+func Validation(params func(string) string, rules []ValidationItem, opts ...Option) (map[string]string, string, error) {
+	schema, err := Compile(rules)
+	if err != nil {
+		return nil, err.(*ConfigError).Key, err
 	}
+	return schema.Validate(params, opts...)
+}
 
-	return data, "", nil
+// 参数验证，执行全部验证项并返回所有错误
+func ValidationAll(params func(string) string, rules []ValidationItem, opts ...Option) (map[string]string, error) {
+	schema, err := Compile(rules)
+	if err != nil {
+		return nil, err
+	}
+	return schema.ValidateAll(params, opts...)
 }
 
 // 是否为空或未提交
-func ValidationRequired(rule *ValidationItem, _ int, val string) error {
+func ValidationRequired(rule *ValidationItem, index int, val string) error {
 	if val == "" {
-		return fmt.Errorf(ValidateValCanNotEmpty, rule.Name)
+		return newFieldError(rule, index, val, MsgRequired, ValidateValCanNotEmpty)
 	}
 	return nil
 }
@@ -109,28 +113,28 @@ func ValidationIn(rule *ValidationItem, index int, val string) error {
 				return nil
 			}
 		}
-		return fmt.Errorf(ValidateValNotExists, rule.Name)
+		return newFieldError(rule, index, val, MsgIn, ValidateValNotExists)
 	}
 	return nil
 }
 
 // 是否为布尔类型
-func ValidationBool(rule *ValidationItem, _ int, val string) error {
+func ValidationBool(rule *ValidationItem, index int, val string) error {
 	if val != "" {
 		_, err := strconv.ParseBool(val)
 		if err != nil {
-			return fmt.Errorf(ValidateValMustBool, rule.Name)
+			return newFieldError(rule, index, val, MsgBool, ValidateValMustBool)
 		}
 	}
 	return nil
 }
 
 // 是否为整数类型
-func ValidationInteger(rule *ValidationItem, _ int, val string) error {
+func ValidationInteger(rule *ValidationItem, index int, val string) error {
 	if val != "" {
 		_, err := strconv.Atoi(val)
 		if err != nil {
-			return fmt.Errorf(ValidateValMustInteger, rule.Name)
+			return newFieldError(rule, index, val, MsgInteger, ValidateValMustInteger)
 		}
 	}
 	return nil
@@ -138,98 +142,28 @@ func ValidationInteger(rule *ValidationItem, _ int, val string) error {
 
 // 字符串长度或数值是否在范围内
 func ValidationBetween(rule *ValidationItem, index int, val string) error {
-	if val != "" {
-		switch reflect.TypeOf(rule.Rules[index].Data).String() {
-		case "[]int":
-			size := rule.Rules[index].Data.([]int)
-			valInt, err := strconv.Atoi(val)
-			if err == nil && size[0] <= valInt && valInt <= size[1] {
-				return nil
-			}
-			return fmt.Errorf(ValidateValNotBetweenInt, rule.Name, size[0], size[1])
-		case "[]float64":
-			size := rule.Rules[index].Data.([]float64)
-			valFloat, err := strconv.ParseFloat(val, 64)
-			if err == nil && size[0] <= valFloat && valFloat <= size[1] {
-				return nil
-			}
-			return fmt.Errorf(ValidateValNotBetweenFloat, rule.Name, size[0], size[1])
-		case "[]string":
-			sizeStr := rule.Rules[index].Data.([]string)
-			size := [2]int{}
-			size[0], _ = strconv.Atoi(sizeStr[0])
-			size[1], _ = strconv.Atoi(sizeStr[1])
-			if size[0] <= utf8.RuneCount([]byte(val)) && utf8.RuneCount([]byte(val)) <= size[1] {
-				return nil
-			}
-			return fmt.Errorf(ValidateValNotBetweenStr, rule.Name, size[0], size[1])
-		}
-		return fmt.Errorf(ValidateMethodNotAllowSth, "ValidationBetween",
-			reflect.TypeOf(rule.Rules[index].Data).String())
-	}
-	return nil
+	return validationSize(rule, index, val, "between", "ValidationBetween")
 }
 
 // 字符串长度或数值是否小于最小值
 func ValidationMin(rule *ValidationItem, index int, val string) error {
-	if val != "" {
-		switch reflect.TypeOf(rule.Rules[index].Data).String() {
-		case "int":
-			size := rule.Rules[index].Data.(int)
-			valInt, err := strconv.Atoi(val)
-			if err == nil && size <= valInt {
-				return nil
-			}
-			return fmt.Errorf(ValidateValNotMinInt, rule.Name, size)
-		case "float64":
-			size := rule.Rules[index].Data.(float64)
-			valFloat, err := strconv.ParseFloat(val, 64)
-			if err == nil && size <= valFloat {
-				return nil
-			}
-			return fmt.Errorf(ValidateValNotMinFloat, rule.Name, size)
-		case "string":
-			sizeStr := rule.Rules[index].Data.(string)
-			size, _ := strconv.Atoi(sizeStr)
-			if size <= utf8.RuneCount([]byte(val)) {
-				return nil
-			}
-			return fmt.Errorf(ValidateValNotMinStr, rule.Name, size)
-		}
-		return fmt.Errorf(ValidateMethodNotAllowSth, "ValidationBetween",
-			reflect.TypeOf(rule.Rules[index].Data).String())
-	}
-	return nil
+	return validationSize(rule, index, val, "min", "ValidationMin")
 }
 
 // 字符串长度或数值是否超过最大值
 func ValidationMax(rule *ValidationItem, index int, val string) error {
+	return validationSize(rule, index, val, "max", "ValidationMax")
+}
+
+// between、min、max 规则的公共逻辑，name 为调用方法对应的规则，不取决于 rule.Rules[index].Rule
+func validationSize(rule *ValidationItem, index int, val string, name, method string) error {
 	if val != "" {
-		switch reflect.TypeOf(rule.Rules[index].Data).String() {
-		case "int":
-			size := rule.Rules[index].Data.(int)
-			valInt, err := strconv.Atoi(val)
-			if err == nil && size >= valInt {
-				return nil
-			}
-			return fmt.Errorf(ValidateValNotMaxInt, rule.Name, size)
-		case "float64":
-			size := rule.Rules[index].Data.(float64)
-			valFloat, err := strconv.ParseFloat(val, 64)
-			if err == nil && size >= valFloat {
-				return nil
-			}
-			return fmt.Errorf(ValidateValNotMaxFloat, rule.Name, size)
-		case "string":
-			sizeStr := rule.Rules[index].Data.(string)
-			size, _ := strconv.Atoi(sizeStr)
-			if size >= utf8.RuneCount([]byte(val)) {
-				return nil
-			}
-			return fmt.Errorf(ValidateValNotMaxStr, rule.Name, size)
+		size, err := parseSizeRule(name, rule.Rules[index].Data)
+		if err != nil {
+			return fmt.Errorf(ValidateMethodNotAllowSth, method,
+				reflect.TypeOf(rule.Rules[index].Data).String())
 		}
-		return fmt.Errorf(ValidateMethodNotAllowSth, "ValidationBetween",
-			reflect.TypeOf(rule.Rules[index].Data).String())
+		return size.validate(rule, index, val)
 	}
 	return nil
 }
@@ -250,7 +184,7 @@ func ValidationArrayInArray(rule *ValidationItem, index int, val string) error {
 					}
 				}
 				if !exists {
-					return fmt.Errorf(ValidateValArrayNotInArray, rule.Name, listStr)
+					return newFieldError(rule, index, val, MsgArrayInArray, ValidateValArrayNotInArray, listStr)
 				}
 			}
 			return nil
@@ -260,7 +194,7 @@ func ValidationArrayInArray(rule *ValidationItem, index int, val string) error {
 				exists := false
 				vInt, err := strconv.Atoi(v)
 				if err != nil {
-					return fmt.Errorf(ValidateValArrayNotInArray, rule.Name, listInt)
+					return newFieldError(rule, index, val, MsgArrayInArray, ValidateValArrayNotInArray, listInt)
 				}
 				for _, lv := range listInt {
 					if vInt == lv {
@@ -268,7 +202,7 @@ func ValidationArrayInArray(rule *ValidationItem, index int, val string) error {
 					}
 				}
 				if !exists {
-					return fmt.Errorf(ValidateValArrayNotInArray, rule.Name, listInt)
+					return newFieldError(rule, index, val, MsgArrayInArray, ValidateValArrayNotInArray, listInt)
 				}
 			}
 			return nil
@@ -285,7 +219,7 @@ func ValidationFilterChar(rule *ValidationItem, index int, val string) error {
 		chars := rule.Rules[index].Data.([]string)
 		for _, v := range chars {
 			if strings.Index(val, v) >= 0 {
-				return fmt.Errorf(ValidateValExistsFilterChar, rule.Name, chars)
+				return newFieldError(rule, index, val, MsgFilterChar, ValidateValExistsFilterChar, chars)
 			}
 		}
 	}
@@ -298,7 +232,7 @@ func ValidationRegexp(rule *ValidationItem, index int, val string) error {
 		data := rule.Rules[index].Data.(ValidationRegexpRule)
 		m, _ := regexp.MatchString(data.Regexp, val)
 		if !m {
-			return fmt.Errorf(data.Msg, rule.Name)
+			return newFieldError(rule, index, val, data.Msg, data.Msg)
 		}
 	}
 	return nil
@@ -309,7 +243,7 @@ func ValidationFunc(rule *ValidationItem, index int, val string) error {
 	if val != "" {
 		data := rule.Rules[index].Data.(ValidationFuncRule)
 		if !data.Func(val) {
-			return fmt.Errorf(data.Msg, rule.Name)
+			return newFieldError(rule, index, val, data.Msg, data.Msg)
 		}
 	}
 	return nil
@@ -322,7 +256,7 @@ func ValidationDistinct(rule *ValidationItem, index int, val string) error {
 		for i := 0; i < len(valList); i++ {
 			for j := i + 1; j < len(valList); j++ {
 				if valList[i] == valList[j] {
-					return fmt.Errorf(ValidateValMustDistinct, rule.Name, valList[i])
+					return newFieldError(rule, index, val, MsgDistinct, ValidateValMustDistinct, valList[i])
 				}
 			}
 		}
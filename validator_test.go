@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+/**
+ * @Author: BoolDesign
+ * @Email: booldesign@163.com
+ * @Date: 2021/4/12 11:02
+ * @Desc:
+ */
+
+func testParams(params map[string]string) func(string) string {
+	return func(key string) string {
+		return params[key]
+	}
+}
+
+func TestValidation(t *testing.T) {
+	rules := []ValidationItem{
+		{Key: "orgId", Name: "组织机构id", Rules: []ValidationRule{{Rule: "required"}, {Rule: "integer"}}},
+		{Key: "status", Name: "状态", Rules: []ValidationRule{{Rule: "in", Data: []string{"ENABLED", "DISABLED"}}}},
+	}
+
+	data, key, err := Validation(testParams(map[string]string{"orgId": "1", "status": "ENABLED"}), rules)
+	if err != nil || key != "" || data["orgId"] != "1" {
+		t.Errorf("Validation() failed. key: %s, err: %v", key, err)
+	}
+
+	_, key, err = Validation(testParams(map[string]string{"orgId": "a", "status": "DELETED"}), rules)
+	if err == nil || key != "orgId" {
+		t.Errorf("Validation() should stop at orgId, got key: %s", key)
+	}
+}
+
+func TestValidationAll(t *testing.T) {
+	rules := []ValidationItem{
+		{Key: "orgId", Name: "组织机构id", Rules: []ValidationRule{{Rule: "integer"}, {Rule: "min", Data: 10}}},
+		{Key: "status", Name: "状态", Rules: []ValidationRule{{Rule: "in", Data: []string{"ENABLED", "DISABLED"}}}},
+		{Key: "keywords", Name: "关键词", Rules: []ValidationRule{{Rule: "required"}}},
+	}
+
+	data, err := ValidationAll(testParams(map[string]string{"orgId": "a", "status": "DELETED", "keywords": "go"}), rules)
+	if err == nil {
+		t.Fatal("ValidationAll() should fail.")
+	}
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("ValidationAll() returned %T, want ValidationErrors", err)
+	}
+	if len(errs) != 2 || errs[0].Key != "orgId" || errs[1].Key != "status" {
+		t.Errorf("ValidationAll() unexpected errors: %v", errs)
+	}
+	if len(errs.Get("orgId")) != 2 {
+		t.Errorf("ValidationAll() orgId should have 2 errors, got %v", errs.Get("orgId"))
+	}
+	if fields := errs.Fields(); len(fields) != 2 {
+		t.Errorf("ValidationErrors.Fields() = %v", fields)
+	}
+	if data["keywords"] != "go" {
+		t.Errorf("ValidationAll() should keep valid values, got %v", data)
+	}
+}
+
+func TestValidationFieldError(t *testing.T) {
+	rules := []ValidationItem{
+		{Key: "pageSize", Name: "每页记录条数", Rules: []ValidationRule{{Rule: "between", Data: []int{1, 100}}}},
+	}
+
+	_, _, err := Validation(testParams(map[string]string{"pageSize": "101"}), rules)
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("Validation() returned %T, want *FieldError", err)
+	}
+	if fe.Key != "pageSize" || fe.Rule != "between" || fe.Value != "101" || fe.Args[1] != 100 {
+		t.Errorf("Validation() unexpected FieldError: %+v", fe)
+	}
+	if fe.Error() != "每页记录条数 必须是 1 - 100 之间的整数" {
+		t.Errorf("FieldError.Error() = %s", fe.Error())
+	}
+}
+
+// 旧的规则方法按调用的方法验证，与 ValidationRule.Rule 无关
+func TestValidationSizeMethod(t *testing.T) {
+	item := &ValidationItem{Key: "n", Name: "n", Rules: []ValidationRule{{Rule: "between", Data: 5}}}
+	if err := ValidationMin(item, 0, "6"); err != nil {
+		t.Errorf("ValidationMin() failed. %v", err)
+	}
+	if err := ValidationMin(item, 0, "4"); err == nil {
+		t.Error("ValidationMin() should fail")
+	}
+	if err := ValidationMax(item, 0, "6"); err == nil {
+		t.Error("ValidationMax() should fail")
+	}
+	if err := ValidationBetween(item, 0, "4"); err == nil {
+		t.Error("ValidationBetween() with int data should be rejected")
+	}
+}